@@ -0,0 +1,223 @@
+package googledirectory
+
+import (
+	"context"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+
+	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/googleapi"
+)
+
+// aliasOwner is a user or group that alias email addresses belong to
+type aliasOwner struct {
+	Id           string
+	PrimaryEmail string
+	Aliases      []string
+}
+
+// aliasOwnerKind describes how to look up the users, or the groups, that alias email addresses belong to
+type aliasOwnerKind struct {
+	// The name of the kind of owner, i.e. user or group
+	name string
+	// Lists every owner, streaming the owners to the child hydrate
+	list func(ctx context.Context, d *plugin.QueryData) error
+	// Gets the owner with the given primary email address, alias email address or unique ID
+	get func(service *admin.Service, key string) (interface{}, error)
+	// Returns the owner streamed by list or returned by get
+	owner func(item interface{}) *aliasOwner
+	// Lists the aliases of the owner with the given unique ID
+	listAliases func(service *admin.Service, id string) (*admin.Aliases, error)
+}
+
+var userAliasOwnerKind = aliasOwnerKind{
+	name: "user",
+	list: func(ctx context.Context, d *plugin.QueryData) error {
+		// The query's limit applies to the aliases, not to the users
+		return streamDirectoryUsers(ctx, d, 500)
+	},
+	get: func(service *admin.Service, key string) (interface{}, error) {
+		return service.Users.Get(key).Do()
+	},
+	owner: func(item interface{}) *aliasOwner {
+		user := item.(*admin.User)
+		return &aliasOwner{Id: user.Id, PrimaryEmail: user.PrimaryEmail, Aliases: user.Aliases}
+	},
+	listAliases: func(service *admin.Service, id string) (*admin.Aliases, error) {
+		return service.Users.Aliases.List(id).Do()
+	},
+}
+
+var groupAliasOwnerKind = aliasOwnerKind{
+	name: "group",
+	list: func(ctx context.Context, d *plugin.QueryData) error {
+		// The query's limit applies to the aliases, not to the groups
+		return streamDirectoryGroups(ctx, d, 200)
+	},
+	get: func(service *admin.Service, key string) (interface{}, error) {
+		return service.Groups.Get(key).Do()
+	},
+	owner: func(item interface{}) *aliasOwner {
+		group := item.(*admin.Group)
+		return &aliasOwner{Id: group.Id, PrimaryEmail: group.Email, Aliases: group.Aliases}
+	},
+	listAliases: func(service *admin.Service, id string) (*admin.Aliases, error) {
+		return service.Groups.Aliases.List(id).Do()
+	},
+}
+
+//// TABLE DEFINITION
+
+func tableGoogleDirectoryAlias(name string, description string, kind aliasOwnerKind) *plugin.Table {
+	return &plugin.Table{
+		Name:        name,
+		Description: description,
+		List: &plugin.ListConfig{
+			ParentHydrate: listDirectoryAliasOwners(kind),
+			Hydrate:       listDirectoryAliases(kind),
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "primary_email",
+					Require: plugin.Optional,
+				},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("alias"),
+			Hydrate:    getDirectoryAlias(kind),
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "alias",
+				Description: "The alias email address.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "primary_email",
+				Description: "The primary email address of the " + kind.name + " the alias belongs to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The unique ID of the " + kind.name + " the alias belongs to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "etag",
+				Description: "A hash of the metadata, used to ensure there were no concurrent modifications to the resource when attempting an update.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "kind",
+				Description: "The type of the API resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+// Lists the owners whose aliases are listed; only the given owner, if the primary_email qual is set
+func listDirectoryAliasOwners(kind aliasOwnerKind) plugin.HydrateFunc {
+	return func(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+		primaryEmail := d.EqualsQualString("primary_email")
+		if primaryEmail == "" {
+			return nil, kind.list(ctx, d)
+		}
+
+		// Create service
+		service, err := AdminService(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+
+		item, err := kind.get(service, primaryEmail)
+		if err != nil {
+			// Return nil, if given user or group is not present
+			if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+				return nil, nil
+			}
+			return nil, err
+		}
+		d.StreamListItem(ctx, item)
+
+		return nil, nil
+	}
+}
+
+func listDirectoryAliases(kind aliasOwnerKind) plugin.HydrateFunc {
+	return func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+		owner := kind.owner(h.Item)
+
+		// Return nil, if the user or group doesn't have any aliases
+		if len(owner.Aliases) == 0 {
+			return nil, nil
+		}
+
+		// Create service
+		service, err := AdminService(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := kind.listAliases(service, owner.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		aliases, err := toAliases(resp.Aliases)
+		if err != nil {
+			return nil, err
+		}
+
+		streamItems(ctx, d, aliases)
+
+		return nil, nil
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func getDirectoryAlias(kind aliasOwnerKind) plugin.HydrateFunc {
+	return func(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+		plugin.Logger(ctx).Trace("getDirectoryAlias", "kind", kind.name)
+
+		// Create service
+		service, err := AdminService(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+
+		alias := d.EqualsQuals["alias"].GetStringValue()
+
+		// Return nil, if no input provided
+		if alias == "" {
+			return nil, nil
+		}
+
+		// The API resolves an alias email address to the user or group it belongs to
+		item, err := kind.get(service, alias)
+		if err != nil {
+			return nil, err
+		}
+		owner := kind.owner(item)
+
+		// Return nil, if the address is not an alias of the owner, e.g. it is the primary email address
+		for _, ownerAlias := range owner.Aliases {
+			if strings.EqualFold(ownerAlias, alias) {
+				return &admin.Alias{
+					Alias:        ownerAlias,
+					Id:           owner.Id,
+					PrimaryEmail: owner.PrimaryEmail,
+					Kind:         "admin#directory#alias",
+				}, nil
+			}
+		}
+
+		return nil, nil
+	}
+}
@@ -8,6 +8,7 @@ type googledirectoryConfig struct {
 	CredentialFile        *string `hcl:"credential_file"`
 	Credentials           *string `hcl:"credentials"`
 	ImpersonatedUserEmail *string `hcl:"impersonated_user_email"`
+	MaskVerificationCodes *bool   `hcl:"mask_verification_codes"`
 	TokenPath             *string `hcl:"token_path"`
 }
 
@@ -0,0 +1,49 @@
+package googledirectory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// errStopPaging is returned from a page callback to stop requesting further pages
+var errStopPaging = errors.New("stop paging")
+
+// Returns the number of results to request per page; the API's maximum page size, unless the query's limit is lower
+func maxResults(d *plugin.QueryData, pageSize int64) int64 {
+	limit := d.QueryContext.Limit
+	if limit != nil && *limit > 0 && *limit < pageSize {
+		return *limit
+	}
+	return pageSize
+}
+
+// Streams the items of every page returned by the given Pages function of a list call. No further pages
+// are requested once the context has been cancelled, e.g. because the query's limit has been hit.
+func streamPages[P any, T any](ctx context.Context, d *plugin.QueryData, pages func(context.Context, func(P) error) error, items func(P) []T) error {
+	err := pages(ctx, func(page P) error {
+		if !streamItems(ctx, d, items(page)) {
+			return errStopPaging
+		}
+		return nil
+	})
+	if errors.Is(err, errStopPaging) {
+		return nil
+	}
+	return err
+}
+
+// Streams the given items, for list calls that return all results at once.
+// Returns false if the context has been cancelled, e.g. because the query's limit has been hit.
+func streamItems[T any](ctx context.Context, d *plugin.QueryData, items []T) bool {
+	for _, item := range items {
+		d.StreamListItem(ctx, item)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if plugin.IsCancelled(ctx) {
+			return false
+		}
+	}
+	return true
+}
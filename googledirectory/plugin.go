@@ -11,6 +11,7 @@ import (
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	"github.com/turbot/steampipe-plugin-sdk/v5/rate_limiter"
 )
 
 const pluginName = "steampipe-plugin-googledirectory"
@@ -26,18 +27,65 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 		ConnectionConfigSchema: &plugin.ConnectionConfigSchema{
 			NewInstance: ConfigInstance,
 		},
-		TableMap: map[string]*plugin.Table{
-			"googledirectory_domain":          tableGoogleDirectoryDomain(ctx),
-			"googledirectory_domain_alias":    tableGoogleDirectoryDomainAlias(ctx),
-			"googledirectory_group":           tableGoogleDirectoryGroup(ctx),
-			"googledirectory_group_member":    tableGoogleDirectoryGroupMember(ctx),
-			"googledirectory_org_unit":        tableGoogleDirectoryOrgUnit(ctx),
-			"googledirectory_privilege":       tableGoogleDirectoryPrivilege(ctx),
-			"googledirectory_role":            tableGoogleDirectoryRole(ctx),
-			"googledirectory_role_assignment": tableGoogleDirectoryRoleAssignment(ctx),
-			"googledirectory_user":            tableGoogleDirectoryUser(ctx),
+		SchemaMode:   plugin.SchemaModeDynamic,
+		TableMapFunc: pluginTableDefinitions,
+		RateLimiters: []*rate_limiter.Definition{
+			// Listing the members of every group makes one members list call per group, made concurrently by the SDK for googledirectory_group_member.
+			// Limit the rate of these calls per connection, to stay within the Directory API's per-user quota; the tables expanding nested
+			// groups, googledirectory_group_member_transitive and googledirectory_user_effective_privilege, wait for the same limiter.
+			{
+				Name:       "googledirectory_group_member_list",
+				FillRate:   10,
+				BucketSize: 10,
+				Scope:      []string{"connection", "service", "action"},
+				Where:      "service = 'admin' and action = 'members.list'",
+			},
 		},
 	}
 
 	return p
 }
+
+// pluginTableDefinitions returns the tables of the plugin; googledirectory_user has a column per field of the
+// custom user schemas defined in the connection's account, so the tables are built per connection
+func pluginTableDefinitions(ctx context.Context, td *plugin.TableMapData) (map[string]*plugin.Table, error) {
+	// Discover the custom user schemas; if they can't be listed, e.g. due to missing scopes,
+	// the user table is still created, without the custom schema field columns
+	customSchemas, err := listUserCustomSchemas(ctx, td.Connection, td.ConnectionCache)
+	if err != nil {
+		plugin.Logger(ctx).Warn("pluginTableDefinitions", "connection", td.Connection.Name, "custom_schema_error", err)
+	}
+
+	tables := map[string]*plugin.Table{
+		"googledirectory_chromeos_device":          tableGoogleDirectoryChromeOSDevice(ctx),
+		"googledirectory_customer":                 tableGoogleDirectoryCustomer(ctx),
+		"googledirectory_domain":                   tableGoogleDirectoryDomain(ctx),
+		"googledirectory_domain_alias":             tableGoogleDirectoryDomainAlias(ctx),
+		"googledirectory_group":                    tableGoogleDirectoryGroup(ctx),
+		"googledirectory_group_alias":              tableGoogleDirectoryGroupAlias(ctx),
+		"googledirectory_group_has_member":         tableGoogleDirectoryGroupHasMember(ctx),
+		"googledirectory_group_member":             tableGoogleDirectoryGroupMember(ctx),
+		"googledirectory_group_member_transitive":  tableGoogleDirectoryGroupMemberTransitive(ctx),
+		"googledirectory_mobile_device":            tableGoogleDirectoryMobileDevice(ctx),
+		"googledirectory_org_unit":                 tableGoogleDirectoryOrgUnit(ctx),
+		"googledirectory_org_unit_tree":            tableGoogleDirectoryOrgUnitTree(ctx),
+		"googledirectory_privilege":                tableGoogleDirectoryPrivilege(ctx),
+		"googledirectory_privilege_tree":           tableGoogleDirectoryPrivilegeTree(ctx),
+		"googledirectory_resource_building":        tableGoogleDirectoryResourceBuilding(ctx),
+		"googledirectory_resource_calendar":        tableGoogleDirectoryResourceCalendar(ctx),
+		"googledirectory_resource_feature":         tableGoogleDirectoryResourceFeature(ctx),
+		"googledirectory_role":                     tableGoogleDirectoryRole(ctx),
+		"googledirectory_role_assignment":          tableGoogleDirectoryRoleAssignment(ctx),
+		"googledirectory_user":                     tableGoogleDirectoryUser(ctx, customSchemas),
+		"googledirectory_user_alias":               tableGoogleDirectoryUserAlias(ctx),
+		"googledirectory_user_asp":                 tableGoogleDirectoryUserAsp(ctx),
+		"googledirectory_user_effective_privilege": tableGoogleDirectoryUserEffectivePrivilege(ctx),
+		"googledirectory_user_photo":               tableGoogleDirectoryUserPhoto(ctx),
+		"googledirectory_user_schema":              tableGoogleDirectoryUserSchema(ctx),
+		"googledirectory_user_schema_field":        tableGoogleDirectoryUserSchemaField(ctx),
+		"googledirectory_user_token":               tableGoogleDirectoryUserToken(ctx),
+		"googledirectory_verification_code":        tableGoogleDirectoryVerificationCode(ctx),
+	}
+
+	return tables, nil
+}
@@ -3,24 +3,48 @@ package googledirectory
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/option"
 
+	"github.com/turbot/steampipe-plugin-sdk/v5/connection"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	admin "google.golang.org/api/admin/directory/v1"
+	groupssettings "google.golang.org/api/groupssettings/v1"
 )
 
+// adminServiceScopes are the scopes requested by the admin service used by most tables
+var adminServiceScopes = []string{
+	admin.AdminDirectoryDomainReadonlyScope,
+	admin.AdminDirectoryGroupReadonlyScope,
+	admin.AdminDirectoryOrgunitReadonlyScope,
+	admin.AdminDirectoryRolemanagementReadonlyScope,
+	admin.AdminDirectoryUserReadonlyScope,
+}
+
 func AdminService(ctx context.Context, d *plugin.QueryData) (*admin.Service, error) {
+	return adminService(ctx, d.Connection, d.ConnectionCache, adminServiceScopes...)
+}
+
+// AdminServiceWithScope creates an admin service whose token only requests the given scope, for the tables that
+// need a scope other than adminServiceScopes. With domain-wide delegation, Google refuses a token if any of the
+// requested scopes hasn't been delegated; so only the tables needing a scope fail if that scope isn't delegated.
+func AdminServiceWithScope(ctx context.Context, d *plugin.QueryData, scope string) (*admin.Service, error) {
+	return adminService(ctx, d.Connection, d.ConnectionCache, scope)
+}
+
+// Creates the admin service for the given connection, also when no query is running, e.g. while building the table map
+func adminService(ctx context.Context, conn *plugin.Connection, connectionCache *connection.ConnectionCache, scopes ...string) (*admin.Service, error) {
 	// have we already created and cached the service?
-	serviceCacheKey := "googledirectory.admin"
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
+	serviceCacheKey := "googledirectory.admin." + strings.Join(scopes, ",")
+	if cachedData, ok := connectionCache.Get(ctx, serviceCacheKey); ok {
 		return cachedData.(*admin.Service), nil
 	}
 
 	// so it was not in cache - create service
-	opts, err := getSessionConfig(ctx, d)
+	opts, err := getSessionConfig(ctx, conn, connectionCache, scopes...)
 	if err != nil {
 		return nil, err
 	}
@@ -32,17 +56,44 @@ func AdminService(ctx context.Context, d *plugin.QueryData) (*admin.Service, err
 	}
 
 	// cache the service
-	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
+	connectionCache.Set(ctx, serviceCacheKey, svc)
+
+	return svc, nil
+}
+
+// GroupsSettingsService creates the Groups Settings service, used to get the access and posting settings of groups
+func GroupsSettingsService(ctx context.Context, d *plugin.QueryData) (*groupssettings.Service, error) {
+	// have we already created and cached the service?
+	serviceCacheKey := "googledirectory.groupssettings"
+	if cachedData, ok := d.ConnectionCache.Get(ctx, serviceCacheKey); ok {
+		return cachedData.(*groupssettings.Service), nil
+	}
+
+	// so it was not in cache - create service
+	opts, err := getSessionConfig(ctx, d.Connection, d.ConnectionCache, groupssettings.AppsGroupsSettingsScope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create service
+	svc, err := groupssettings.NewService(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// cache the service
+	d.ConnectionCache.Set(ctx, serviceCacheKey, svc)
 
 	return svc, nil
 }
 
-func getSessionConfig(ctx context.Context, d *plugin.QueryData) ([]option.ClientOption, error) {
+// Returns the client options to authenticate with; the token requests the given scopes when using domain-wide delegation
+func getSessionConfig(ctx context.Context, conn *plugin.Connection, connectionCache *connection.ConnectionCache, scopes ...string) ([]option.ClientOption, error) {
 	opts := []option.ClientOption{}
 
 	// Get credential file path, and user to impersonate from config (if mentioned)
 	var credentialContent, tokenPath string
-	googledirectoryConfig := GetConfig(d.Connection)
+	googledirectoryConfig := GetConfig(conn)
 
 	// 'credential_file' in connection config is DEPRECATED, and will be removed in future release
 	// use `credentials` instead
@@ -58,7 +109,7 @@ func getSessionConfig(ctx context.Context, d *plugin.QueryData) ([]option.Client
 
 	// If credential path provided, use domain-wide delegation
 	if credentialContent != "" {
-		ts, err := getTokenSource(ctx, d)
+		ts, err := getTokenSource(ctx, conn, connectionCache, scopes...)
 		if err != nil {
 			return nil, err
 		}
@@ -79,19 +130,19 @@ func getSessionConfig(ctx context.Context, d *plugin.QueryData) ([]option.Client
 	return nil, nil
 }
 
-// Returns a JWT TokenSource using the configuration and the HTTP client from the provided context
-func getTokenSource(ctx context.Context, d *plugin.QueryData) (oauth2.TokenSource, error) {
+// Returns a JWT TokenSource requesting the given scopes, using the configuration and the HTTP client from the provided context
+func getTokenSource(ctx context.Context, conn *plugin.Connection, connectionCache *connection.ConnectionCache, scopes ...string) (oauth2.TokenSource, error) {
 	// NOTE: based on https://developers.google.com/admin-sdk/directory/v1/guides/delegation#go
 
 	// have we already created and cached the token?
-	cacheKey := "googledirectory.token_source"
-	if ts, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+	cacheKey := "googledirectory.token_source." + strings.Join(scopes, ",")
+	if ts, ok := connectionCache.Get(ctx, cacheKey); ok {
 		return ts.(oauth2.TokenSource), nil
 	}
 
 	// Get credential file path, and user to impersonate from config (if mentioned)
 	var impersonateUser string
-	googledirectoryConfig := GetConfig(d.Connection)
+	googledirectoryConfig := GetConfig(conn)
 
 	// Read credential from JSON string, or from the given path
 	// NOTE: 'credential_file' in connection config is DEPRECATED, and will be removed in future release
@@ -119,14 +170,7 @@ func getTokenSource(ctx context.Context, d *plugin.QueryData) (oauth2.TokenSourc
 	}
 
 	// Authorize the request
-	config, err := google.JWTConfigFromJSON(
-		[]byte(credentialContent),
-		admin.AdminDirectoryDomainReadonlyScope,
-		admin.AdminDirectoryGroupReadonlyScope,
-		admin.AdminDirectoryOrgunitReadonlyScope,
-		admin.AdminDirectoryRolemanagementReadonlyScope,
-		admin.AdminDirectoryUserReadonlyScope,
-	)
+	config, err := google.JWTConfigFromJSON([]byte(credentialContent), scopes...)
 	if err != nil {
 		return nil, err
 	}
@@ -135,7 +179,7 @@ func getTokenSource(ctx context.Context, d *plugin.QueryData) (oauth2.TokenSourc
 	ts := config.TokenSource(ctx)
 
 	// cache the token source
-	d.ConnectionManager.Cache.Set(cacheKey, ts)
+	connectionCache.Set(ctx, cacheKey, ts)
 
 	return ts, nil
 }
@@ -0,0 +1,330 @@
+package googledirectory
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+//// TABLE DEFINITION
+
+func tableGoogleDirectoryChromeOSDevice(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googledirectory_chromeos_device",
+		Description: "ChromeOS devices defined in the Google Workspace directory.",
+		List: &plugin.ListConfig{
+			Hydrate: listDirectoryChromeOSDevices,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "customer_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "org_unit_path",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "order_by",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "query",
+					Require: plugin.Optional,
+				},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "device_id",
+					Require: plugin.Required,
+				},
+				{
+					Name:    "customer_id",
+					Require: plugin.Optional,
+				},
+			},
+			Hydrate: getDirectoryChromeOSDevice,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "device_id",
+				Description: "The unique ID of the ChromeOS device.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "serial_number",
+				Description: "The ChromeOS device serial number as entered by the administrator.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "model",
+				Description: "The device's model information.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "os_version",
+				Description: "The ChromeOS version of the device.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "status",
+				Description: "The status of the device.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "org_unit_path",
+				Description: "The full parent path with the organizational unit's name associated with the device.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "org_unit_id",
+				Description: "The unique ID of the organizational unit the device belongs to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "last_sync",
+				Description: "The date and time the device was last synchronized with the policy settings in the Admin console.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "annotated_user",
+				Description: "The user of the device as noted by the administrator.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "annotated_location",
+				Description: "The address or location of the device as noted by the administrator.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "annotated_asset_id",
+				Description: "The asset identifier as noted by an administrator or specified during enrollment.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "customer_id",
+				Description: "The customer ID to retrieve all account ChromeOS devices.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("customer_id"),
+			},
+			{
+				Name:        "auto_update_expiration",
+				Description: "The timestamp after which the device will stop receiving ChromeOS updates or support.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("AutoUpdateExpiration").Transform(transform.UnixMsToTimestamp),
+			},
+			{
+				Name:        "boot_mode",
+				Description: "The boot mode for the device.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "deprovision_reason",
+				Description: "The reason for deprovisioning.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "device_license_type",
+				Description: "The type of license applied to the device.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "etag",
+				Description: "A hash of the metadata, used to ensure there were no concurrent modifications to the resource when attempting an update.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ethernet_mac_address",
+				Description: "The device's MAC address on the ethernet network interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "firmware_version",
+				Description: "The ChromeOS device's firmware version.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "first_enrollment_time",
+				Description: "The date and time the device was enrolled for the first time.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "kind",
+				Description: "The type of the API resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "last_deprovision_timestamp",
+				Description: "The date and time the device was last deprovisioned.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "last_enrollment_time",
+				Description: "The date and time the device was last enrolled.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "mac_address",
+				Description: "The device's wireless MAC address.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "manufacture_date",
+				Description: "The date the device was manufactured in yyyy-mm-dd format.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "meid",
+				Description: "The Mobile Equipment Identifier (MEID) or the International Mobile Equipment Identity (IMEI) for the 3G mobile card in a mobile device.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "notes",
+				Description: "Notes about this device added by the administrator.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "order_number",
+				Description: "The device's order number.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "platform_version",
+				Description: "The ChromeOS device's platform version.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "support_end_date",
+				Description: "The final date the device will be supported.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "system_ram_total",
+				Description: "The total RAM on the device, in bytes.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "will_auto_renew",
+				Description: "Indicates whether the device will be auto renewed, or not.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "order_by",
+				Description: "Device property used to sort the results. Possible values are: annotatedLocation, annotatedUser, lastSync, notes, serialNumber and status.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("order_by"),
+			},
+			{
+				Name:        "query",
+				Description: "Filter string to [filter](https://developers.google.com/admin-sdk/directory/v1/list-query-operators) ChromeOS devices.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("query"),
+			},
+			{
+				Name:        "active_time_ranges",
+				Description: "A list of active time ranges of the device.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "cpu_info",
+				Description: "Information regarding CPU specs in the device.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "disk_volume_reports",
+				Description: "Reports of disk space and other info about mounted/connected volumes.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "last_known_network",
+				Description: "Contains last known network.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "os_update_status",
+				Description: "The status of the OS updates for the device.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "recent_users",
+				Description: "A list of recent device users, in descending order, by last login time.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "tpm_version_info",
+				Description: "Trusted Platform Module (TPM) information of the device.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectoryChromeOSDevices(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create service
+	service, err := AdminServiceWithScope(ctx, d, admin.AdminDirectoryDeviceChromeosReadonlyScope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default value to my_customer, to represent current account
+	customerID := "my_customer"
+	if d.EqualsQuals["customer_id"] != nil {
+		customerID = d.EqualsQuals["customer_id"].GetStringValue()
+	}
+
+	// By default, API can return maximum 300 records in a single page
+	resp := service.Chromeosdevices.List(customerID).Projection("FULL").MaxResults(maxResults(d, 300))
+	if d.EqualsQuals["org_unit_path"] != nil {
+		resp.OrgUnitPath(d.EqualsQuals["org_unit_path"].GetStringValue())
+	}
+	if d.EqualsQuals["order_by"] != nil {
+		resp.OrderBy(d.EqualsQuals["order_by"].GetStringValue())
+	}
+	if d.EqualsQuals["query"] != nil {
+		resp.Query(d.EqualsQuals["query"].GetStringValue())
+	}
+	if err := streamPages(ctx, d, resp.Pages, func(page *admin.ChromeOsDevices) []*admin.ChromeOsDevice { return page.Chromeosdevices }); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getDirectoryChromeOSDevice(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	plugin.Logger(ctx).Trace("getDirectoryChromeOSDevice")
+
+	// Create service
+	service, err := AdminServiceWithScope(ctx, d, admin.AdminDirectoryDeviceChromeosReadonlyScope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default value to my_customer, to represent current account
+	customerID := "my_customer"
+	if d.EqualsQuals["customer_id"] != nil {
+		customerID = d.EqualsQuals["customer_id"].GetStringValue()
+	}
+	deviceID := d.EqualsQuals["device_id"].GetStringValue()
+
+	// Return nil, if no input provided
+	if deviceID == "" {
+		return nil, nil
+	}
+
+	resp, err := service.Chromeosdevices.Get(customerID, deviceID).Projection("FULL").Do()
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,137 @@
+package googledirectory
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+//// TABLE DEFINITION
+
+func tableGoogleDirectoryCustomer(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googledirectory_customer",
+		Description: "The Google Workspace customer account the connection is authenticated against.",
+		List: &plugin.ListConfig{
+			Hydrate:           listDirectoryCustomers,
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("customer_id"),
+			Hydrate:    getDirectoryCustomer,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "customer_id",
+				Description: "The customer ID the account was queried with, either the my_customer alias or the unique ID for the customer's Google Workspace account.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.From(customerQueriedID),
+			},
+			{
+				Name:        "id",
+				Description: "The unique ID for the customer's Google Workspace account.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "customer_domain",
+				Description: "The customer's primary domain name string.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "alternate_email",
+				Description: "The customer's secondary contact email address.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "creation_time",
+				Description: "The customer's creation time.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("CustomerCreationTime"),
+			},
+			{
+				Name:        "language",
+				Description: "The customer's ISO 639-2 language code.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "phone_number",
+				Description: "The customer's contact phone number in E.164 format.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "etag",
+				Description: "A hash of the metadata, used to ensure there were no concurrent modifications to the resource when attempting an update.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "kind",
+				Description: "The type of the API resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "postal_address",
+				Description: "The customer's postal address information.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectoryCustomers(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create service
+	service, err := AdminServiceWithScope(ctx, d, admin.AdminDirectoryCustomerReadonlyScope)
+	if err != nil {
+		return nil, err
+	}
+
+	// The my_customer alias represents the account the connection is authenticated against
+	resp, err := service.Customers.Get("my_customer").Do()
+	if err != nil {
+		return nil, err
+	}
+	d.StreamListItem(ctx, resp)
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getDirectoryCustomer(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	plugin.Logger(ctx).Trace("getDirectoryCustomer")
+
+	// Create service
+	service, err := AdminServiceWithScope(ctx, d, admin.AdminDirectoryCustomerReadonlyScope)
+	if err != nil {
+		return nil, err
+	}
+
+	customerID := d.EqualsQuals["customer_id"].GetStringValue()
+
+	// Return nil, if no input provided
+	if customerID == "" {
+		return nil, nil
+	}
+
+	resp, err := service.Customers.Get(customerID).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+// Keeps the customer ID given in the query, so the row matches a query on the my_customer alias
+func customerQueriedID(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	if customerID := d.KeyColumnQuals["customer_id"]; len(customerID) > 0 {
+		return customerID[0].Value.GetStringValue(), nil
+	}
+	return d.HydrateItem.(*admin.Customer).Id, nil
+}
@@ -94,14 +94,7 @@ func listDirectoryDomains(ctx context.Context, d *plugin.QueryData, _ *plugin.Hy
 	if err != nil {
 		return nil, err
 	}
-	for _, user := range resp.Domains {
-		d.StreamListItem(ctx, user)
-
-		// Context can be cancelled due to manual cancellation or the limit has been hit
-		if plugin.IsCancelled(ctx) {
-			break
-		}
-	}
+	streamItems(ctx, d, resp.Domains)
 
 	return nil, nil
 }
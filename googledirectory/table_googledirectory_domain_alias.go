@@ -106,14 +106,7 @@ func listDirectoryDomainAliases(ctx context.Context, d *plugin.QueryData, _ *plu
 	if err != nil {
 		return nil, err
 	}
-	for _, domainAlias := range resp.DomainAliases {
-		d.StreamListItem(ctx, domainAlias)
-
-		// Context can be cancelled due to manual cancellation or the limit has been hit
-		if plugin.IsCancelled(ctx) {
-			break
-		}
-	}
+	streamItems(ctx, d, resp.DomainAliases)
 
 	return nil, nil
 }
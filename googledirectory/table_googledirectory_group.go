@@ -2,6 +2,7 @@ package googledirectory
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -9,7 +10,9 @@ import (
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 
+	"golang.org/x/oauth2"
 	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/googleapi"
 )
 
 //// TABLE DEFINITION
@@ -33,6 +36,10 @@ func tableGoogleDirectoryGroup(_ context.Context) *plugin.Table {
 					Name:    "query",
 					Require: plugin.Optional,
 				},
+				{
+					Name:    "member_key",
+					Require: plugin.Optional,
+				},
 			},
 			ShouldIgnoreError: isNotFoundError([]string{"404"}),
 		},
@@ -40,6 +47,15 @@ func tableGoogleDirectoryGroup(_ context.Context) *plugin.Table {
 			KeyColumns: plugin.AnyColumn([]string{"id", "email"}),
 			Hydrate:    getDirectoryGroup,
 		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getDirectoryGroupSettings,
+				// Return null group settings, rather than failing the query, if the Groups Settings API can't be accessed
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreError: isGroupSettingsUnavailableError,
+				},
+			},
+		},
 		Columns: []*plugin.Column{
 			{
 				Name:        "name",
@@ -93,6 +109,12 @@ func tableGoogleDirectoryGroup(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromQual("query"),
 			},
+			{
+				Name:        "member_key",
+				Description: "The email address or unique ID of a user or group, to list only the groups it is a direct member of.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("member_key"),
+			},
 			{
 				Name:        "aliases",
 				Description: "A list of the group's alias email addresses.",
@@ -103,6 +125,151 @@ func tableGoogleDirectoryGroup(_ context.Context) *plugin.Table {
 				Description: "A list of the group's non-editable alias email addresses that are outside of the account's primary domain or subdomains.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "allow_external_members",
+				Description: "Indicates whether members external to the organization can join the group, or not.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getDirectoryGroupSettings,
+				Transform:   transform.FromField("AllowExternalMembers").NullIfZero().Transform(transform.ToBool),
+			},
+			{
+				Name:        "allow_web_posting",
+				Description: "Indicates whether members can post to the group from the web, or not.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getDirectoryGroupSettings,
+				Transform:   transform.FromField("AllowWebPosting").NullIfZero().Transform(transform.ToBool),
+			},
+			{
+				Name:        "archive_only",
+				Description: "Indicates whether the group is archive-only, i.e. it is inactive and doesn't accept new messages, or not.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getDirectoryGroupSettings,
+				Transform:   transform.FromField("ArchiveOnly").NullIfZero().Transform(transform.ToBool),
+			},
+			{
+				Name:        "is_archived",
+				Description: "Indicates whether the contents of the group are archived, or not.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getDirectoryGroupSettings,
+				Transform:   transform.FromField("IsArchived").NullIfZero().Transform(transform.ToBool),
+			},
+			{
+				Name:        "enable_collaborative_inbox",
+				Description: "Indicates whether a collaborative inbox remains turned on for the group, or not.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getDirectoryGroupSettings,
+				Transform:   transform.FromField("EnableCollaborativeInbox").NullIfZero().Transform(transform.ToBool),
+			},
+			{
+				Name:        "include_in_global_address_list",
+				Description: "Indicates whether the group is included in the Global Address List, or not.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getDirectoryGroupSettings,
+				Transform:   transform.FromField("IncludeInGlobalAddressList").NullIfZero().Transform(transform.ToBool),
+			},
+			{
+				Name:        "members_can_post_as_the_group",
+				Description: "Indicates whether members can post using the group's email address, or not.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getDirectoryGroupSettings,
+				Transform:   transform.FromField("MembersCanPostAsTheGroup").NullIfZero().Transform(transform.ToBool),
+			},
+			{
+				Name:        "message_moderation_level",
+				Description: "The moderation level of incoming messages. Possible values are: MODERATE_ALL_MESSAGES, MODERATE_NON_MEMBERS, MODERATE_NEW_MEMBERS and MODERATE_NONE.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDirectoryGroupSettings,
+			},
+			{
+				Name:        "spam_moderation_level",
+				Description: "Specifies moderation levels for messages detected as spam. Possible values are: ALLOW, MODERATE, SILENTLY_MODERATE and REJECT.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDirectoryGroupSettings,
+			},
+			{
+				Name:        "reply_to",
+				Description: "Specifies who receives the default reply.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDirectoryGroupSettings,
+			},
+			{
+				Name:        "custom_reply_to",
+				Description: "An email address used when replying to a message, if reply_to is set to REPLY_TO_CUSTOM.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDirectoryGroupSettings,
+			},
+			{
+				Name:        "default_sender",
+				Description: "The default sender for members who can post messages as the group. Possible values are: DEFAULT_SELF and GROUP.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDirectoryGroupSettings,
+			},
+			{
+				Name:        "who_can_join",
+				Description: "Permission to join the group. Possible values are: ANYONE_CAN_JOIN, ALL_IN_DOMAIN_CAN_JOIN, INVITED_CAN_JOIN and CAN_REQUEST_TO_JOIN.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDirectoryGroupSettings,
+			},
+			{
+				Name:        "who_can_post_message",
+				Description: "Permissions to post messages. Possible values are: NONE_CAN_POST, ALL_MANAGERS_CAN_POST, ALL_MEMBERS_CAN_POST, ALL_OWNERS_CAN_POST, ALL_IN_DOMAIN_CAN_POST and ANYONE_CAN_POST.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDirectoryGroupSettings,
+			},
+			{
+				Name:        "who_can_view_membership",
+				Description: "Permissions to view membership. Possible values are: ALL_IN_DOMAIN_CAN_VIEW, ALL_MEMBERS_CAN_VIEW, ALL_MANAGERS_CAN_VIEW and ALL_OWNERS_CAN_VIEW.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDirectoryGroupSettings,
+			},
+			{
+				Name:        "who_can_view_group",
+				Description: "Permissions to view group messages. Possible values are: ANYONE_CAN_VIEW, ALL_IN_DOMAIN_CAN_VIEW, ALL_MEMBERS_CAN_VIEW, ALL_MANAGERS_CAN_VIEW and ALL_OWNERS_CAN_VIEW.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDirectoryGroupSettings,
+			},
+			{
+				Name:        "who_can_discover_group",
+				Description: "Specifies the set of users for whom the group is discoverable. Possible values are: ANYONE_CAN_DISCOVER, ALL_IN_DOMAIN_CAN_DISCOVER and ALL_MEMBERS_CAN_DISCOVER.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDirectoryGroupSettings,
+			},
+			{
+				Name:        "who_can_contact_owner",
+				Description: "Permission to contact the owner of the group via the web UI. Possible values are: ALL_IN_DOMAIN_CAN_CONTACT, ALL_MANAGERS_CAN_CONTACT, ALL_MEMBERS_CAN_CONTACT and ANYONE_CAN_CONTACT.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDirectoryGroupSettings,
+			},
+			{
+				Name:        "who_can_leave_group",
+				Description: "Permission to leave the group. Possible values are: ALL_MANAGERS_CAN_LEAVE, ALL_MEMBERS_CAN_LEAVE and NONE_CAN_LEAVE.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDirectoryGroupSettings,
+			},
+			{
+				Name:        "who_can_moderate_members",
+				Description: "Specifies who can manage members. Possible values are: ALL_MEMBERS, OWNERS_AND_MANAGERS, OWNERS_ONLY and NONE.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDirectoryGroupSettings,
+			},
+			{
+				Name:        "who_can_moderate_content",
+				Description: "Specifies who can moderate content. Possible values are: ALL_MEMBERS, OWNERS_AND_MANAGERS, OWNERS_ONLY and NONE.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDirectoryGroupSettings,
+			},
+			{
+				Name:        "who_can_assist_content",
+				Description: "Specifies who can moderate metadata. Possible values are: ALL_MEMBERS, OWNERS_AND_MANAGERS, MANAGERS_ONLY, OWNERS_ONLY and NONE.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDirectoryGroupSettings,
+			},
+			{
+				Name:        "primary_language",
+				Description: "The primary language for the group.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDirectoryGroupSettings,
+			},
 		},
 	}
 }
@@ -110,10 +277,16 @@ func tableGoogleDirectoryGroup(_ context.Context) *plugin.Table {
 //// LIST FUNCTION
 
 func listDirectoryGroups(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// By default, API can return maximum 200 records in a single page
+	return nil, streamDirectoryGroups(ctx, d, maxResults(d, 200))
+}
+
+// Streams the groups matching the query's quals, requesting the given number of groups per page
+func streamDirectoryGroups(ctx context.Context, d *plugin.QueryData, pageSize int64) error {
 	// Create service
 	service, err := AdminService(ctx, d)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	var queryFilter, query string
@@ -144,33 +317,19 @@ func listDirectoryGroups(ctx context.Context, d *plugin.QueryData, _ *plugin.Hyd
 		customerID = d.EqualsQuals["customer_id"].GetStringValue()
 	}
 
-	// By default, API can return maximum 200 records in a single page
-	maxResult := int64(200)
-
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if *limit < maxResult {
-			maxResult = *limit
-		}
-	}
-
-	resp := service.Groups.List().Customer(customerID).Query(query).MaxResults(maxResult)
-	if err := resp.Pages(ctx, func(page *admin.Groups) error {
-		for _, group := range page.Groups {
-			d.StreamListItem(ctx, group)
+	resp := service.Groups.List().MaxResults(pageSize)
 
-			// Context can be cancelled due to manual cancellation or the limit has been hit
-			if plugin.IsCancelled(ctx) {
-				page.NextPageToken = ""
-				break
-			}
+	// The groups a user or group is a direct member of can't be filtered by customer or query
+	if d.EqualsQuals["member_key"] != nil {
+		if d.EqualsQuals["customer_id"] != nil || d.EqualsQuals["query"] != nil {
+			return errors.New("member_key can't be specified with customer_id or query")
 		}
-		return nil
-	}); err != nil {
-		return nil, err
+		resp.UserKey(d.EqualsQuals["member_key"].GetStringValue())
+	} else {
+		resp.Customer(customerID).Query(query)
 	}
 
-	return nil, err
+	return streamPages(ctx, d, resp.Pages, func(page *admin.Groups) []*admin.Group { return page.Groups })
 }
 
 //// HYDRATE FUNCTIONS
@@ -206,3 +365,37 @@ func getDirectoryGroup(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 
 	return resp, nil
 }
+
+func getDirectoryGroupSettings(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	plugin.Logger(ctx).Trace("getDirectoryGroupSettings")
+
+	// Create service
+	service, err := GroupsSettingsService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// The Groups Settings API identifies groups by their email address
+	email := h.Item.(*admin.Group).Email
+
+	resp, err := service.Groups.Get(email).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Returns true if the Groups Settings API can't be accessed, i.e. the API isn't enabled in the Google Cloud project,
+// or the groups settings scope hasn't been delegated to the service account
+func isGroupSettingsUnavailableError(err error) bool {
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) {
+		return gerr.Code == 403
+	}
+	var rerr *oauth2.RetrieveError
+	if errors.As(err, &rerr) {
+		return rerr.ErrorCode == "unauthorized_client"
+	}
+	return false
+}
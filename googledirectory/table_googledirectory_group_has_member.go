@@ -0,0 +1,80 @@
+package googledirectory
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+type groupHasMember struct {
+	GroupKey  string
+	MemberKey string
+	IsMember  bool
+}
+
+//// TABLE DEFINITION
+
+func tableGoogleDirectoryGroupHasMember(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googledirectory_group_has_member",
+		Description: "Checks whether a user or group is a direct or nested member of a group in the Google Workspace directory.",
+		List: &plugin.ListConfig{
+			Hydrate: listDirectoryGroupHasMember,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "group_key",
+					Require: plugin.Required,
+				},
+				{
+					Name:    "member_key",
+					Require: plugin.Required,
+				},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "group_key",
+				Description: "The email address, alias or unique ID of the group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "member_key",
+				Description: "The email address, alias or unique ID of the user or group to check membership for.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "is_member",
+				Description: "Indicates whether the user or group is a member of the group, either directly or through nested groups.",
+				Type:        proto.ColumnType_BOOL,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectoryGroupHasMember(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create service
+	service, err := AdminService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	groupKey := d.EqualsQualString("group_key")
+	memberKey := d.EqualsQualString("member_key")
+
+	// Return nil, if no input provided
+	if groupKey == "" || memberKey == "" {
+		return nil, nil
+	}
+
+	resp, err := service.Members.HasMember(groupKey, memberKey).Do()
+	if err != nil {
+		return nil, err
+	}
+	d.StreamListItem(ctx, &groupHasMember{GroupKey: groupKey, MemberKey: memberKey, IsMember: resp.IsMember})
+
+	return nil, nil
+}
@@ -2,6 +2,8 @@ package googledirectory
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
@@ -11,6 +13,12 @@ import (
 	"google.golang.org/api/googleapi"
 )
 
+type groupMember struct {
+	GroupId    string
+	GroupEmail string
+	Member     *admin.Member
+}
+
 //// TABLE DEFINITION
 
 func tableGoogleDirectoryGroupMember(_ context.Context) *plugin.Table {
@@ -18,14 +26,21 @@ func tableGoogleDirectoryGroupMember(_ context.Context) *plugin.Table {
 		Name:        "googledirectory_group_member",
 		Description: "Group members defined in the Google Workspace directory.",
 		List: &plugin.ListConfig{
-			Hydrate: listDirectoryGroupMembers,
+			ParentHydrate: listDirectoryGroupMemberGroups,
+			Hydrate:       listDirectoryGroupMembers,
+			Tags:          map[string]string{"service": "admin", "action": "members.list"},
 			KeyColumns: []*plugin.KeyColumn{
 				{
 					Name:    "group_id",
-					Require: plugin.Required,
+					Require: plugin.Optional,
 				},
 				{
-					Name:    "role",
+					Name:      "role",
+					Require:   plugin.Optional,
+					Operators: []string{"<>", "="},
+				},
+				{
+					Name:    "include_derived_membership",
 					Require: plugin.Optional,
 				},
 			},
@@ -40,48 +55,66 @@ func tableGoogleDirectoryGroupMember(_ context.Context) *plugin.Table {
 				Name:        "group_id",
 				Description: "Specifies the ID of the group, the user belongs.",
 				Type:        proto.ColumnType_STRING,
-				Transform:   transform.FromQual("group_id"),
+			},
+			{
+				Name:        "group_email",
+				Description: "Specifies the email address of the group, the user belongs.",
+				Type:        proto.ColumnType_STRING,
 			},
 			{
 				Name:        "id",
 				Description: "The unique ID of the group member.",
 				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Member.Id"),
 			},
 			{
 				Name:        "email",
 				Description: "Specifies the member's email address.",
 				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Member.Email"),
 			},
 			{
 				Name:        "role",
 				Description: "Specifies the role of the member in a group.",
 				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Member.Role"),
 			},
 			{
 				Name:        "status",
 				Description: "Specifies the status of the member.",
 				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Member.Status"),
 			},
 			{
 				Name:        "delivery_settings",
 				Description: "Defines mail delivery preferences of member.",
 				Type:        proto.ColumnType_STRING,
 				Hydrate:     getDirectoryGroupMember,
+				Transform:   transform.FromField("Member.DeliverySettings"),
 			},
 			{
 				Name:        "etag",
 				Description: "A hash of the metadata, used to ensure there were no concurrent modifications to the resource when attempting an update.",
 				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Member.Etag"),
 			},
 			{
 				Name:        "kind",
 				Description: "The type of the API resource.",
 				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Member.Kind"),
 			},
 			{
 				Name:        "type",
 				Description: "The type of group member.",
 				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Member.Type"),
+			},
+			{
+				Name:        "include_derived_membership",
+				Description: "Whether to list indirect memberships, i.e. the members of the group's nested groups. Defaults to false.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromQual("include_derived_membership"),
 			},
 		},
 	}
@@ -89,50 +122,75 @@ func tableGoogleDirectoryGroupMember(_ context.Context) *plugin.Table {
 
 //// LIST FUNCTION
 
-func listDirectoryGroupMembers(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+// listDirectoryGroupMemberGroups lists the groups whose members are listed; the given group if group_id is
+// specified, otherwise every group in the account
+func listDirectoryGroupMemberGroups(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	groupID := d.EqualsQualString("group_id")
+	if groupID == "" {
+		// The query's limit applies to the members, not to the groups
+		return nil, streamDirectoryGroups(ctx, d, 200)
+	}
+
 	// Create service
 	service, err := AdminService(ctx, d)
 	if err != nil {
 		return nil, err
 	}
-	groupID := d.EqualsQuals["group_id"].GetStringValue()
 
-	var role string
-	if d.EqualsQuals["role"] != nil {
-		role = d.EqualsQuals["role"].GetStringValue()
+	group, err := service.Groups.Get(groupID).Do()
+	if err != nil {
+		// Return nil, if given group is not present
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			return nil, nil
+		}
+		return nil, err
 	}
+	d.StreamListItem(ctx, group)
 
-	// By default, API can return maximum 200 records in a single page
-	maxResult := int64(200)
+	return nil, nil
+}
 
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if *limit < maxResult {
-			maxResult = *limit
-		}
+func listDirectoryGroupMembers(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	group := h.Item.(*admin.Group)
+
+	// Create service
+	service, err := AdminService(ctx, d)
+	if err != nil {
+		return nil, err
 	}
 
-	resp := service.Members.List(groupID).Roles(role).MaxResults(maxResult)
-	if err := resp.Pages(ctx, func(page *admin.Members) error {
-		for _, member := range page.Members {
-			d.StreamListItem(ctx, member)
+	// Keep the group ID as given in the query, since it may be the group's email address or alias
+	groupID := group.Id
+	if d.EqualsQualString("group_id") != "" {
+		groupID = d.EqualsQualString("group_id")
+	}
 
-			// Context can be cancelled due to manual cancellation or the limit has been hit
-			if plugin.IsCancelled(ctx) {
-				page.NextPageToken = ""
-				break
-			}
+	// Return nil, if the role quals can't match any member
+	role, ok := buildGroupMemberRolesFilter(d.Quals)
+	if !ok {
+		return nil, nil
+	}
+
+	// By default, API can return maximum 200 records in a single page
+	resp := service.Members.List(group.Id).Roles(role).MaxResults(maxResults(d, 200))
+	if d.EqualsQuals["include_derived_membership"] != nil {
+		resp.IncludeDerivedMembership(d.EqualsQuals["include_derived_membership"].GetBoolValue())
+	}
+	if err := streamPages(ctx, d, resp.Pages, func(page *admin.Members) []*groupMember {
+		members := []*groupMember{}
+		for _, member := range page.Members {
+			members = append(members, &groupMember{GroupId: groupID, GroupEmail: group.Email, Member: member})
 		}
-		return nil
+		return members
 	}); err != nil {
 		// Return nil, if given group is not present
-		if err.(*googleapi.Error).Code == 404 {
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
 			return nil, nil
 		}
 		return nil, err
 	}
 
-	return nil, err
+	return nil, nil
 }
 
 //// HYDRATE FUNCTIONS
@@ -146,11 +204,12 @@ func getDirectoryGroupMember(ctx context.Context, d *plugin.QueryData, h *plugin
 		return nil, err
 	}
 
-	var groupID, memberID string
+	var groupID, groupEmail, memberID string
 	if h.Item != nil {
-		data := h.Item.(*admin.Member)
-		groupID = d.EqualsQuals["group_id"].GetStringValue()
-		memberID = data.Id
+		data := h.Item.(*groupMember)
+		groupID = data.GroupId
+		groupEmail = data.GroupEmail
+		memberID = data.Member.Id
 	} else {
 		groupID = d.EqualsQuals["group_id"].GetStringValue()
 		memberID = d.EqualsQuals["id"].GetStringValue()
@@ -166,5 +225,50 @@ func getDirectoryGroupMember(ctx context.Context, d *plugin.QueryData, h *plugin
 		return nil, err
 	}
 
-	return resp, nil
+	// Look up the group's email address, if it isn't already known from the listed row
+	if groupEmail == "" {
+		group, err := service.Groups.Get(groupID).Do()
+		if err != nil {
+			return nil, err
+		}
+		groupEmail = group.Email
+	}
+
+	return &groupMember{GroupId: groupID, GroupEmail: groupEmail, Member: resp}, nil
+}
+
+// groupMemberRoles are the roles a member can have in a group
+var groupMemberRoles = []string{"OWNER", "MANAGER", "MEMBER"}
+
+// Returns the comma-separated list of roles to list group members for, based on the role quals, e.g.
+// role in ('OWNER', 'MANAGER') and role <> 'MEMBER' both translate to OWNER,MANAGER.
+// Returns false if the quals can't be satisfied by any role.
+func buildGroupMemberRolesFilter(quals plugin.KeyColumnQualMap) (string, bool) {
+	if quals["role"] == nil {
+		return "", true
+	}
+
+	roles := groupMemberRoles
+	for _, q := range quals["role"].Quals {
+		var values []string
+		if listValue := q.Value.GetListValue(); listValue != nil {
+			for _, value := range listValue.Values {
+				values = append(values, strings.ToUpper(value.GetStringValue()))
+			}
+		} else {
+			values = append(values, strings.ToUpper(q.Value.GetStringValue()))
+		}
+
+		roles = slices.DeleteFunc(slices.Clone(roles), func(role string) bool {
+			if q.Operator == "<>" {
+				return slices.Contains(values, role)
+			}
+			return !slices.Contains(values, role)
+		})
+	}
+
+	if len(roles) == 0 {
+		return "", false
+	}
+	return strings.Join(roles, ","), true
 }
@@ -0,0 +1,349 @@
+package googledirectory
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+//// TABLE DEFINITION
+
+func tableGoogleDirectoryMobileDevice(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googledirectory_mobile_device",
+		Description: "Mobile devices managed in the Google Workspace directory.",
+		List: &plugin.ListConfig{
+			Hydrate: listDirectoryMobileDevices,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "customer_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "order_by",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "projection",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "query",
+					Require: plugin.Optional,
+				},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "resource_id",
+					Require: plugin.Required,
+				},
+				{
+					Name:    "customer_id",
+					Require: plugin.Optional,
+				},
+			},
+			Hydrate: getDirectoryMobileDevice,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "resource_id",
+				Description: "The unique ID the API service uses to identify the mobile device.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "device_id",
+				Description: "The serial number for a Google Sync mobile device.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "model",
+				Description: "The mobile device's model name.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "os",
+				Description: "The mobile device's operating system.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "type",
+				Description: "The type of mobile device.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "status",
+				Description: "The device's status.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "serial_number",
+				Description: "The device's serial number.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "imei",
+				Description: "The device's International Mobile Station Equipment Identity (IMEI) number.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "meid",
+				Description: "The device's Mobile Equipment Identifier (MEID) number.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "device_compromised_status",
+				Description: "The compromised device status, e.g. whether the device is rooted or jailbroken.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "encryption_status",
+				Description: "The device's encryption status.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "first_sync",
+				Description: "The date and time the device was initially synchronized with the policy settings in the Admin console.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "last_sync",
+				Description: "The date and time the device was last synchronized with the policy settings in the Admin console.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "customer_id",
+				Description: "The customer ID to retrieve all account mobile devices.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("customer_id"),
+			},
+			{
+				Name:        "adb_status",
+				Description: "Indicates whether Android Debug Bridge (adb) is enabled on the device, or not.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "baseband_version",
+				Description: "The device's baseband version.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "bootloader_version",
+				Description: "The mobile device's bootloader version.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "brand",
+				Description: "The mobile device's brand.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "build_number",
+				Description: "The device's operating system build number.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "default_language",
+				Description: "The default locale used on the device.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "developer_options_status",
+				Description: "Indicates whether developer options are enabled on the device, or not.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "device_password_status",
+				Description: "The device's password status.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "etag",
+				Description: "A hash of the metadata, used to ensure there were no concurrent modifications to the resource when attempting an update.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "hardware",
+				Description: "The mobile device's hardware.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "hardware_id",
+				Description: "The IMEI/MEID unique identifier for Android hardware.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "kernel_version",
+				Description: "The device's kernel version.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "kind",
+				Description: "The type of the API resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "managed_account_is_on_owner_profile",
+				Description: "Indicates whether the managed account is on the owner or primary profile of the device, or not.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "manufacturer",
+				Description: "The mobile device's manufacturer.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "network_operator",
+				Description: "The mobile device's mobile or network operator.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "privilege",
+				Description: "The DMAgentPermission of the device.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "release_version",
+				Description: "The mobile device's release version.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "security_patch_level",
+				Description: "The mobile device's security patch level.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("SecurityPatchLevel").Transform(transform.UnixMsToTimestamp),
+			},
+			{
+				Name:        "supports_work_profile",
+				Description: "Indicates whether the device supports work profile, or not.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "unknown_sources_status",
+				Description: "Indicates whether installation of apps from unknown sources is enabled on the device, or not.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "user_agent",
+				Description: "The mobile device's user agent.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "wifi_mac_address",
+				Description: "The device's MAC address on Wi-Fi networks.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "order_by",
+				Description: "Device property used to sort the results. Possible values are: deviceId, email, lastSync, model, name, os, status and type.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("order_by"),
+			},
+			{
+				Name:        "projection",
+				Description: "Restrict information returned to a set of selected fields. Possible values are: BASIC and FULL. Defaults to FULL.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("projection"),
+			},
+			{
+				Name:        "query",
+				Description: "Filter string to [filter](https://developers.google.com/admin-sdk/directory/v1/search-operators) mobile devices.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("query"),
+			},
+			{
+				Name:        "applications",
+				Description: "A list of applications installed on the device.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "email",
+				Description: "A list of the owner's email addresses.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "name",
+				Description: "A list of the owner's user names.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "other_accounts_info",
+				Description: "A list of accounts added on the device.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectoryMobileDevices(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create service
+	service, err := AdminServiceWithScope(ctx, d, admin.AdminDirectoryDeviceMobileReadonlyScope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default value to my_customer, to represent current account
+	customerID := "my_customer"
+	if d.EqualsQuals["customer_id"] != nil {
+		customerID = d.EqualsQuals["customer_id"].GetStringValue()
+	}
+
+	// Return all metadata fields, unless asked otherwise
+	projection := "FULL"
+	if d.EqualsQuals["projection"] != nil {
+		projection = d.EqualsQuals["projection"].GetStringValue()
+	}
+
+	// By default, API can return maximum 100 records in a single page
+	resp := service.Mobiledevices.List(customerID).Projection(projection).MaxResults(maxResults(d, 100))
+	if d.EqualsQuals["order_by"] != nil {
+		resp.OrderBy(d.EqualsQuals["order_by"].GetStringValue())
+	}
+	if d.EqualsQuals["query"] != nil {
+		resp.Query(d.EqualsQuals["query"].GetStringValue())
+	}
+	if err := streamPages(ctx, d, resp.Pages, func(page *admin.MobileDevices) []*admin.MobileDevice { return page.Mobiledevices }); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getDirectoryMobileDevice(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	plugin.Logger(ctx).Trace("getDirectoryMobileDevice")
+
+	// Create service
+	service, err := AdminServiceWithScope(ctx, d, admin.AdminDirectoryDeviceMobileReadonlyScope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default value to my_customer, to represent current account
+	customerID := "my_customer"
+	if d.EqualsQuals["customer_id"] != nil {
+		customerID = d.EqualsQuals["customer_id"].GetStringValue()
+	}
+	resourceID := d.EqualsQuals["resource_id"].GetStringValue()
+
+	// Return nil, if no input provided
+	if resourceID == "" {
+		return nil, nil
+	}
+
+	resp, err := service.Mobiledevices.Get(customerID, resourceID).Projection("FULL").Do()
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -2,6 +2,7 @@ package googledirectory
 
 import (
 	"context"
+	"errors"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
@@ -21,6 +22,18 @@ func tableGoogleDirectoryOrgUnit(_ context.Context) *plugin.Table {
 					Name:    "customer_id",
 					Require: plugin.Optional,
 				},
+				{
+					Name:    "parent_org_unit_path",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "root_org_unit_path",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "type",
+					Require: plugin.Optional,
+				},
 			},
 			ShouldIgnoreError: isNotFoundError([]string{"404"}),
 		},
@@ -80,6 +93,18 @@ func tableGoogleDirectoryOrgUnit(_ context.Context) *plugin.Table {
 				Description: "The organizational unit's parent path.",
 				Type:        proto.ColumnType_STRING,
 			},
+			{
+				Name:        "type",
+				Description: "Whether to list all sub-organizational units, or just immediate children. Possible values are: all, children and allIncludingParent. Defaults to all, or to children if parent_org_unit_path is specified.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("type"),
+			},
+			{
+				Name:        "root_org_unit_path",
+				Description: "The full path of the organizational unit whose sub-organizational units are listed, at the depth given by type.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("root_org_unit_path"),
+			},
 		},
 	}
 }
@@ -99,20 +124,38 @@ func listDirectoryOrgUnits(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 		customerID = d.EqualsQuals["customer_id"].GetStringValue()
 	}
 
-	resp, err := service.Orgunits.List(customerID).Do()
-	if err != nil {
-		return nil, err
+	// List every organizational unit by default, since the API only returns the immediate children of the root
+	// organizational unit otherwise
+	listType := "all"
+	if d.EqualsQuals["type"] != nil {
+		listType = d.EqualsQuals["type"].GetStringValue()
 	}
 
-	for _, orgUnit := range resp.OrganizationUnits {
-		d.StreamListItem(ctx, orgUnit)
+	resp := service.Orgunits.List(customerID)
+	if d.EqualsQuals["root_org_unit_path"] != nil {
+		resp.OrgUnitPath(d.EqualsQuals["root_org_unit_path"].GetStringValue())
+	}
 
-		// Context can be cancelled due to manual cancellation or the limit has been hit
-		if plugin.IsCancelled(ctx) {
-			break
+	// If a parent is given, list its immediate children. Any other type would also return organizational units
+	// whose parent is not the given parent, which are then filtered out; use root_org_unit_path instead.
+	if d.EqualsQuals["parent_org_unit_path"] != nil {
+		if d.EqualsQuals["type"] != nil && listType != "children" {
+			return nil, errors.New("type must be children, or not be specified, when parent_org_unit_path is specified; use root_org_unit_path to list the organizational units at any depth below an organizational unit")
 		}
+		if d.EqualsQuals["root_org_unit_path"] != nil {
+			return nil, errors.New("parent_org_unit_path and root_org_unit_path can't both be specified")
+		}
+		resp.OrgUnitPath(d.EqualsQuals["parent_org_unit_path"].GetStringValue())
+		listType = "children"
+	}
+
+	orgUnits, err := resp.Type(listType).Do()
+	if err != nil {
+		return nil, err
 	}
 
+	streamItems(ctx, d, orgUnits.OrganizationUnits)
+
 	return nil, nil
 }
 
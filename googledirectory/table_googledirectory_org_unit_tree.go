@@ -0,0 +1,209 @@
+package googledirectory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+type orgUnitTreeNode struct {
+	OrgUnit                     *admin.OrgUnit
+	Depth                       int
+	IsRoot                      bool
+	AncestorPaths               []string
+	DirectChildCount            int
+	DescendantCount             int
+	EffectiveBlockInheritance   bool
+	BlockInheritanceOrgUnitPath string
+}
+
+//// TABLE DEFINITION
+
+func tableGoogleDirectoryOrgUnitTree(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googledirectory_org_unit_tree",
+		Description: "The hierarchy of organizational units defined in the Google Workspace directory.",
+		List: &plugin.ListConfig{
+			Hydrate: listDirectoryOrgUnitTree,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "customer_id",
+					Require: plugin.Optional,
+				},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The organizational unit's path name.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("OrgUnit.Name"),
+			},
+			{
+				Name:        "org_unit_id",
+				Description: "The unique ID of the organizational unit.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("OrgUnit.OrgUnitId"),
+			},
+			{
+				Name:        "org_unit_path",
+				Description: "The full path to the organizational unit.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("OrgUnit.OrgUnitPath"),
+			},
+			{
+				Name:        "parent_org_unit_id",
+				Description: "The unique ID of the parent organizational unit.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("OrgUnit.ParentOrgUnitId"),
+			},
+			{
+				Name:        "parent_org_unit_path",
+				Description: "The organizational unit's parent path.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("OrgUnit.ParentOrgUnitPath"),
+			},
+			{
+				Name:        "depth",
+				Description: "The depth of the organizational unit in the hierarchy, the root organizational unit being at depth 0.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Depth"),
+			},
+			{
+				Name:        "is_root",
+				Description: "Indicates whether the organizational unit is the root of the hierarchy, or not.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("IsRoot"),
+			},
+			{
+				Name:        "direct_child_count",
+				Description: "The number of organizational units directly under the organizational unit.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("DirectChildCount"),
+			},
+			{
+				Name:        "descendant_count",
+				Description: "The total number of organizational units under the organizational unit, at any depth.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("DescendantCount"),
+			},
+			{
+				Name:        "block_inheritance",
+				Description: "Determines if a sub-organizational unit can inherit the settings of the parent organization.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("OrgUnit.BlockInheritance"),
+			},
+			{
+				Name:        "effective_block_inheritance",
+				Description: "Indicates whether the organizational unit, or any of its ancestors, blocks the inheritance of settings.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("EffectiveBlockInheritance"),
+			},
+			{
+				Name:        "block_inheritance_org_unit_path",
+				Description: "The path of the closest organizational unit, either the organizational unit itself or one of its ancestors, that blocks the inheritance of settings.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "customer_id",
+				Description: "The customer ID to retrieve all account organizational units.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("customer_id"),
+			},
+			{
+				Name:        "description",
+				Description: "A short description of the organizational unit.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("OrgUnit.Description"),
+			},
+			{
+				Name:        "ancestor_paths",
+				Description: "The paths of the organizational unit's ancestors, ordered from the root organizational unit to the parent.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("AncestorPaths"),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectoryOrgUnitTree(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create service
+	service, err := AdminService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default value to my_customer, to represent current account
+	customerID := "my_customer"
+	if d.EqualsQuals["customer_id"] != nil {
+		customerID = d.EqualsQuals["customer_id"].GetStringValue()
+	}
+
+	// List every organizational unit, including the root, to build the complete hierarchy
+	resp, err := service.Orgunits.List(customerID).Type("allIncludingParent").Do()
+	if err != nil {
+		return nil, err
+	}
+
+	streamItems(ctx, d, buildOrgUnitTree(resp.OrganizationUnits))
+
+	return nil, nil
+}
+
+// Returns a node for each of the given organizational units, sorted by path, with its position in the hierarchy
+func buildOrgUnitTree(orgUnits []*admin.OrgUnit) []*orgUnitTreeNode {
+	nodes := map[string]*orgUnitTreeNode{}
+	for _, orgUnit := range orgUnits {
+		nodes[orgUnit.OrgUnitPath] = &orgUnitTreeNode{OrgUnit: orgUnit}
+	}
+
+	for _, node := range nodes {
+		// Walk up to the root, recording the ancestors from the parent upwards
+		var ancestors []*orgUnitTreeNode
+		for parent := nodes[node.OrgUnit.ParentOrgUnitPath]; parent != nil && parent != node; parent = nodes[parent.OrgUnit.ParentOrgUnitPath] {
+			ancestors = append(ancestors, parent)
+			parent.DescendantCount++
+		}
+		if len(ancestors) > 0 {
+			ancestors[0].DirectChildCount++
+		}
+
+		// The closest organizational unit blocking inheritance takes effect
+		for _, n := range append([]*orgUnitTreeNode{node}, ancestors...) {
+			if n.OrgUnit.BlockInheritance {
+				node.EffectiveBlockInheritance = true
+				node.BlockInheritanceOrgUnitPath = n.OrgUnit.OrgUnitPath
+				break
+			}
+		}
+
+		node.AncestorPaths = []string{}
+		for i := len(ancestors) - 1; i >= 0; i-- {
+			node.AncestorPaths = append(node.AncestorPaths, ancestors[i].OrgUnit.OrgUnitPath)
+		}
+		node.IsRoot = node.OrgUnit.OrgUnitPath == "/"
+		node.Depth = len(strings.Split(strings.Trim(node.OrgUnit.OrgUnitPath, "/"), "/"))
+		if node.IsRoot {
+			node.Depth = 0
+		}
+	}
+
+	tree := []*orgUnitTreeNode{}
+	for _, node := range nodes {
+		tree = append(tree, node)
+	}
+	slices.SortFunc(tree, func(a, b *orgUnitTreeNode) int {
+		return strings.Compare(a.OrgUnit.OrgUnitPath, b.OrgUnit.OrgUnitPath)
+	})
+
+	return tree
+}
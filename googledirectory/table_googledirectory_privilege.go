@@ -90,14 +90,7 @@ func listDirectoryPrivileges(ctx context.Context, d *plugin.QueryData, _ *plugin
 		return nil, err
 	}
 
-	for _, role := range resp.Items {
-		d.StreamListItem(ctx, role)
-
-		// Context can be cancelled due to manual cancellation or the limit has been hit
-		if plugin.IsCancelled(ctx) {
-			break
-		}
-	}
+	streamItems(ctx, d, resp.Items)
 
 	return nil, err
 }
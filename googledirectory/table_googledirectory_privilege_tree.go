@@ -0,0 +1,144 @@
+package googledirectory
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+type privilegeTreeNode struct {
+	Privilege           *admin.Privilege
+	ParentPrivilegeName string
+	Depth               int
+	Path                []string
+	IsLeaf              bool
+}
+
+//// TABLE DEFINITION
+
+func tableGoogleDirectoryPrivilegeTree(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googledirectory_privilege_tree",
+		Description: "Privileges defined in the Google Workspace directory, flattened to one row per privilege at any level of the privilege hierarchy.",
+		List: &plugin.ListConfig{
+			Hydrate: listDirectoryPrivilegeTree,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "customer_id",
+					Require: plugin.Optional,
+				},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "privilege_name",
+				Description: "The name of the privilege.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Privilege.PrivilegeName"),
+			},
+			{
+				Name:        "service_name",
+				Description: "The name of the service this privilege is for.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Privilege.ServiceName"),
+			},
+			{
+				Name:        "service_id",
+				Description: "The obfuscated ID of the service this privilege is for.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Privilege.ServiceId"),
+			},
+			{
+				Name:        "parent_privilege_name",
+				Description: "The name of the parent privilege, if the privilege is a child privilege.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "depth",
+				Description: "The depth of the privilege in the privilege hierarchy, top-level privileges being at depth 0.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Depth"),
+			},
+			{
+				Name:        "is_leaf",
+				Description: "Indicates whether the privilege has no child privileges, or not.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("IsLeaf"),
+			},
+			{
+				Name:        "is_ou_scopable",
+				Description: "Indicates if the privilege can be restricted to an organization unit.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Privilege.IsOuScopable"),
+			},
+			{
+				Name:        "customer_id",
+				Description: "The customer ID to retrieve all privileges for a customer.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("customer_id"),
+			},
+			{
+				Name:        "path",
+				Description: "The names of the privileges from the top-level privilege down to the privilege itself.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Path"),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectoryPrivilegeTree(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create service
+	service, err := AdminService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default value to my_customer, to represent current account
+	customerID := "my_customer"
+	if d.EqualsQuals["customer_id"] != nil {
+		customerID = d.EqualsQuals["customer_id"].GetStringValue()
+	}
+
+	resp, err := service.Privileges.List(customerID).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	streamItems(ctx, d, buildPrivilegeTree(resp.Items))
+
+	return nil, nil
+}
+
+// Returns a node for each privilege at any level of the given privilege trees, in depth-first order
+func buildPrivilegeTree(privileges []*admin.Privilege) []*privilegeTreeNode {
+	nodes := []*privilegeTreeNode{}
+
+	var walk func(privileges []*admin.Privilege, parent *privilegeTreeNode)
+	walk = func(privileges []*admin.Privilege, parent *privilegeTreeNode) {
+		for _, privilege := range privileges {
+			node := &privilegeTreeNode{
+				Privilege: privilege,
+				Path:      []string{privilege.PrivilegeName},
+				IsLeaf:    len(privilege.ChildPrivileges) == 0,
+			}
+			if parent != nil {
+				node.ParentPrivilegeName = parent.Privilege.PrivilegeName
+				node.Depth = parent.Depth + 1
+				node.Path = append(append([]string{}, parent.Path...), privilege.PrivilegeName)
+			}
+			nodes = append(nodes, node)
+			walk(privilege.ChildPrivileges, node)
+		}
+	}
+	walk(privileges, nil)
+
+	return nodes
+}
@@ -0,0 +1,146 @@
+package googledirectory
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+//// TABLE DEFINITION
+
+func tableGoogleDirectoryResourceBuilding(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googledirectory_resource_building",
+		Description: "Buildings defined in the Google Workspace directory.",
+		List: &plugin.ListConfig{
+			Hydrate: listDirectoryResourceBuildings,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "customer_id",
+					Require: plugin.Optional,
+				},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "building_id",
+					Require: plugin.Required,
+				},
+				{
+					Name:    "customer_id",
+					Require: plugin.Optional,
+				},
+			},
+			Hydrate: getDirectoryResourceBuilding,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "building_name",
+				Description: "The building name as seen by users in Calendar.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "building_id",
+				Description: "Unique identifier for the building.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "A brief description of the building.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "customer_id",
+				Description: "The customer ID to retrieve all account buildings.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("customer_id"),
+			},
+			{
+				Name:        "etags",
+				Description: "A hash of the metadata, used to ensure there were no concurrent modifications to the resource when attempting an update.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "kind",
+				Description: "The type of the API resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "address",
+				Description: "The postal address of the building.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "coordinates",
+				Description: "The geographic coordinates of the center of the building, expressed as latitude and longitude in decimal degrees.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "floor_names",
+				Description: "The display names for all floors in this building, ordered from lowest to highest.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectoryResourceBuildings(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create service
+	service, err := AdminServiceWithScope(ctx, d, admin.AdminDirectoryResourceCalendarReadonlyScope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default value to my_customer, to represent current account
+	customerID := "my_customer"
+	if d.EqualsQuals["customer_id"] != nil {
+		customerID = d.EqualsQuals["customer_id"].GetStringValue()
+	}
+
+	// By default, API can return maximum 500 records in a single page
+	resp := service.Resources.Buildings.List(customerID).MaxResults(maxResults(d, 500))
+	if err := streamPages(ctx, d, resp.Pages, func(page *admin.Buildings) []*admin.Building { return page.Buildings }); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getDirectoryResourceBuilding(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	plugin.Logger(ctx).Trace("getDirectoryResourceBuilding")
+
+	// Create service
+	service, err := AdminServiceWithScope(ctx, d, admin.AdminDirectoryResourceCalendarReadonlyScope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default value to my_customer, to represent current account
+	customerID := "my_customer"
+	if d.EqualsQuals["customer_id"] != nil {
+		customerID = d.EqualsQuals["customer_id"].GetStringValue()
+	}
+	buildingID := d.EqualsQuals["building_id"].GetStringValue()
+
+	// Return nil, if no input provided
+	if buildingID == "" {
+		return nil, nil
+	}
+
+	resp, err := service.Resources.Buildings.Get(customerID, buildingID).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,207 @@
+package googledirectory
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+//// TABLE DEFINITION
+
+func tableGoogleDirectoryResourceCalendar(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googledirectory_resource_calendar",
+		Description: "Calendar resources defined in the Google Workspace directory.",
+		List: &plugin.ListConfig{
+			Hydrate: listDirectoryResourceCalendars,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "customer_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "order_by",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "query",
+					Require: plugin.Optional,
+				},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "resource_id",
+					Require: plugin.Required,
+				},
+				{
+					Name:    "customer_id",
+					Require: plugin.Optional,
+				},
+			},
+			Hydrate: getDirectoryResourceCalendar,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "resource_name",
+				Description: "The name of the calendar resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_id",
+				Description: "The unique ID for the calendar resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_email",
+				Description: "The read-only email for the calendar resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_category",
+				Description: "The category of the calendar resource. Either CONFERENCE_ROOM or OTHER.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_type",
+				Description: "The type of the calendar resource, intended for non-room resources.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "capacity",
+				Description: "Capacity of a resource, number of seats in a room.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "building_id",
+				Description: "Unique ID for the building a resource is located in.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "floor_name",
+				Description: "Name of the floor a resource is located on.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "floor_section",
+				Description: "Name of the section within a floor a resource is located in.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "customer_id",
+				Description: "The customer ID to retrieve all account calendar resources.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("customer_id"),
+			},
+			{
+				Name:        "etags",
+				Description: "A hash of the metadata, used to ensure there were no concurrent modifications to the resource when attempting an update.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "generated_resource_name",
+				Description: "The read-only auto-generated name of the calendar resource which includes metadata about the resource such as building name, floor, capacity, etc.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "kind",
+				Description: "The type of the API resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_description",
+				Description: "Description of the resource, visible only to admins.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "user_visible_description",
+				Description: "Description of the resource, visible to users and admins.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "order_by",
+				Description: "Field(s) to sort results by in either ascending or descending order. Supported fields include resourceId, resourceName, capacity, buildingId, and floorName.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("order_by"),
+			},
+			{
+				Name:        "query",
+				Description: "Filter string to [filter](https://developers.google.com/admin-sdk/directory/reference/rest/v1/resources.calendars/list#query-parameters) calendar resources.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("query"),
+			},
+			{
+				Name:        "feature_instances",
+				Description: "A list of features of the calendar resource.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectoryResourceCalendars(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create service
+	service, err := AdminServiceWithScope(ctx, d, admin.AdminDirectoryResourceCalendarReadonlyScope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default value to my_customer, to represent current account
+	customerID := "my_customer"
+	if d.EqualsQuals["customer_id"] != nil {
+		customerID = d.EqualsQuals["customer_id"].GetStringValue()
+	}
+
+	// By default, API can return maximum 500 records in a single page
+	resp := service.Resources.Calendars.List(customerID).MaxResults(maxResults(d, 500))
+	if d.EqualsQuals["order_by"] != nil {
+		resp.OrderBy(d.EqualsQuals["order_by"].GetStringValue())
+	}
+	if d.EqualsQuals["query"] != nil {
+		resp.Query(d.EqualsQuals["query"].GetStringValue())
+	}
+	if err := streamPages(ctx, d, resp.Pages, func(page *admin.CalendarResources) []*admin.CalendarResource { return page.Items }); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getDirectoryResourceCalendar(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	plugin.Logger(ctx).Trace("getDirectoryResourceCalendar")
+
+	// Create service
+	service, err := AdminServiceWithScope(ctx, d, admin.AdminDirectoryResourceCalendarReadonlyScope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default value to my_customer, to represent current account
+	customerID := "my_customer"
+	if d.EqualsQuals["customer_id"] != nil {
+		customerID = d.EqualsQuals["customer_id"].GetStringValue()
+	}
+	resourceID := d.EqualsQuals["resource_id"].GetStringValue()
+
+	// Return nil, if no input provided
+	if resourceID == "" {
+		return nil, nil
+	}
+
+	resp, err := service.Resources.Calendars.Get(customerID, resourceID).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -96,19 +96,9 @@ func listDirectoryRoles(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 		customerID = d.EqualsQuals["customer_id"].GetStringValue()
 	}
 
-	resp := service.Roles.List(customerID)
-	if err := resp.Pages(ctx, func(page *admin.Roles) error {
-		for _, role := range page.Items {
-			d.StreamListItem(ctx, role)
-
-			// Context can be cancelled due to manual cancellation or the limit has been hit
-			if plugin.IsCancelled(ctx) {
-				page.NextPageToken = ""
-				break
-			}
-		}
-		return nil
-	}); err != nil {
+	// By default, API can return maximum 100 records in a single page
+	resp := service.Roles.List(customerID).MaxResults(maxResults(d, 100))
+	if err := streamPages(ctx, d, resp.Pages, func(page *admin.Roles) []*admin.Role { return page.Items }); err != nil {
 		return nil, err
 	}
 
@@ -2,12 +2,16 @@ package googledirectory
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 
 	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/googleapi"
 )
 
 //// TABLE DEFINITION
@@ -31,6 +35,10 @@ func tableGoogleDirectoryRoleAssignment(_ context.Context) *plugin.Table {
 					Name:    "user_key",
 					Require: plugin.Optional,
 				},
+				{
+					Name:    "include_indirect_role_assignments",
+					Require: plugin.Optional,
+				},
 			},
 			ShouldIgnoreError: isNotFoundError([]string{"404"}),
 		},
@@ -58,16 +66,40 @@ func tableGoogleDirectoryRoleAssignment(_ context.Context) *plugin.Table {
 				Description: "The unique ID for the role.",
 				Type:        proto.ColumnType_STRING,
 			},
+			{
+				Name:        "role_name",
+				Description: "The name of the role.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getRoleAssignmentRoleName,
+				Transform:   transform.FromValue().NullIfZero(),
+			},
 			{
 				Name:        "assigned_to",
-				Description: "The unique ID of the user this role is assigned to.",
+				Description: "The unique ID of the user or group this role is assigned to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "assignee_type",
+				Description: "The type of the assignee. Possible values are: user and group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "assignee_email",
+				Description: "The primary email address of the user or group this role is assigned to.",
 				Type:        proto.ColumnType_STRING,
+				Hydrate:     getRoleAssignmentAssigneeEmail,
+				Transform:   transform.FromValue().NullIfZero(),
 			},
 			{
 				Name:        "scope_type",
 				Description: "The scope in which this role is assigned.",
 				Type:        proto.ColumnType_STRING,
 			},
+			{
+				Name:        "condition",
+				Description: "The condition associated with this role assignment, following the Cloud IAM condition syntax. A role assignment with a condition only applies to the resources matching the condition, e.g. to security groups only.",
+				Type:        proto.ColumnType_STRING,
+			},
 			{
 				Name:        "customer_id",
 				Description: "The customer ID to retrieve all account roles.",
@@ -80,6 +112,26 @@ func tableGoogleDirectoryRoleAssignment(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromQual("user_key"),
 			},
+			{
+				Name:        "include_indirect_role_assignments",
+				Description: "Whether to list the roles the user given by user_key inherits through group membership. Defaults to false.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromQual("include_indirect_role_assignments"),
+			},
+			{
+				Name:        "is_indirect",
+				Description: "Indicates whether the principal given by user_key inherits the role through membership of the group the role is assigned to, or not. Only set if include_indirect_role_assignments is true.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getRoleAssignmentViaGroupID,
+				Transform:   transform.FromValue().Transform(roleAssignmentIsIndirect),
+			},
+			{
+				Name:        "via_group_id",
+				Description: "The unique ID of the group the principal given by user_key inherits the role through. Only set if include_indirect_role_assignments is true.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getRoleAssignmentViaGroupID,
+				Transform:   transform.FromValue().NullIfZero(),
+			},
 			{
 				Name:        "etag",
 				Description: "A hash of the metadata, used to ensure there were no concurrent modifications to the resource when attempting an update.",
@@ -95,6 +147,13 @@ func tableGoogleDirectoryRoleAssignment(_ context.Context) *plugin.Table {
 				Description: "If the role is restricted to an organization unit, this contains the ID for the organization unit the exercise of this role is restricted to.",
 				Type:        proto.ColumnType_STRING,
 			},
+			{
+				Name:        "org_unit_path",
+				Description: "If the role is restricted to an organization unit, this contains the full path of the organization unit the exercise of this role is restricted to.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getRoleAssignmentOrgUnitPath,
+				Transform:   transform.FromValue().NullIfZero(),
+			},
 		},
 	}
 }
@@ -119,22 +178,17 @@ func listDirectoryRoleAssignments(ctx context.Context, d *plugin.QueryData, _ *p
 		roleId = d.EqualsQuals["role_id"].GetStringValue()
 	}
 
-	resp := service.RoleAssignments.List(customerID).RoleId(roleId)
+	// By default, API can return maximum 200 records in a single page
+	resp := service.RoleAssignments.List(customerID).RoleId(roleId).MaxResults(maxResults(d, 200))
 	if d.EqualsQuals["user_key"] != nil {
 		resp.UserKey(d.EqualsQuals["user_key"].GetStringValue())
-	}
-	if err := resp.Pages(ctx, func(page *admin.RoleAssignments) error {
-		for _, assignment := range page.Items {
-			d.StreamListItem(ctx, assignment)
 
-			// Context can be cancelled due to manual cancellation or the limit has been hit
-			if plugin.IsCancelled(ctx) {
-				page.NextPageToken = ""
-				break
-			}
+		// Indirect role assignments can only be listed for a given user
+		if d.EqualsQuals["include_indirect_role_assignments"] != nil {
+			resp.IncludeIndirectRoleAssignments(d.EqualsQuals["include_indirect_role_assignments"].GetBoolValue())
 		}
-		return nil
-	}); err != nil {
+	}
+	if err := streamPages(ctx, d, resp.Pages, func(page *admin.RoleAssignments) []*admin.RoleAssignment { return page.Items }); err != nil {
 		return nil, err
 	}
 
@@ -171,3 +225,199 @@ func getDirectoryRoleAssignment(ctx context.Context, d *plugin.QueryData, _ *plu
 
 	return resp, nil
 }
+
+// The roles, assignees and organizational units are shared by many role assignments,
+// so their lookups are cached per connection
+var (
+	getRoleAssignmentRoleNameMemoized      = plugin.HydrateFunc(getRoleAssignmentRoleNameUncached).Memoize(withRoleAssignmentCacheKey("role_name"))
+	getRoleAssignmentAssigneeEmailMemoized = plugin.HydrateFunc(getRoleAssignmentAssigneeEmailUncached).Memoize(withRoleAssignmentCacheKey("assignee_email"))
+	getRoleAssignmentOrgUnitPathMemoized   = plugin.HydrateFunc(getRoleAssignmentOrgUnitPathUncached).Memoize(withRoleAssignmentCacheKey("org_unit_path"))
+	getRoleAssignmentUserKeyIDMemoized     = plugin.HydrateFunc(getRoleAssignmentUserKeyIDUncached).Memoize(withRoleAssignmentCacheKey("user_key_id"))
+)
+
+func getRoleAssignmentRoleName(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	return getRoleAssignmentRoleNameMemoized(ctx, d, h)
+}
+
+func getRoleAssignmentAssigneeEmail(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	if h.Item.(*admin.RoleAssignment).AssignedTo == "" {
+		return nil, nil
+	}
+	return getRoleAssignmentAssigneeEmailMemoized(ctx, d, h)
+}
+
+func getRoleAssignmentOrgUnitPath(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	if h.Item.(*admin.RoleAssignment).OrgUnitId == "" {
+		return nil, nil
+	}
+	return getRoleAssignmentOrgUnitPathMemoized(ctx, d, h)
+}
+
+// Returns the ID of the group the role is inherited through, for the indirect role assignments of the principal given by user_key
+func getRoleAssignmentViaGroupID(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Indirect role assignments are only listed for a given user or group, if requested
+	if d.EqualsQualString("user_key") == "" || !d.EqualsQuals["include_indirect_role_assignments"].GetBoolValue() {
+		return nil, nil
+	}
+
+	principalID, err := getRoleAssignmentUserKeyIDMemoized(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+
+	// Roles assigned to the principal itself are direct, whether the principal is a user or a group.
+	// Return an empty group ID, rather than nil, so that is_indirect is false rather than null.
+	assignment := h.Item.(*admin.RoleAssignment)
+	if assignment.AssignedTo == principalID.(string) {
+		return "", nil
+	}
+
+	return assignment.AssignedTo, nil
+}
+
+func getRoleAssignmentRoleNameUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	plugin.Logger(ctx).Trace("getRoleAssignmentRoleNameUncached")
+
+	// Create service
+	service, err := AdminService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment := h.Item.(*admin.RoleAssignment)
+	role, err := service.Roles.Get(roleAssignmentCustomerID(d), strconv.FormatInt(assignment.RoleId, 10)).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	return role.RoleName, nil
+}
+
+func getRoleAssignmentAssigneeEmailUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	plugin.Logger(ctx).Trace("getRoleAssignmentAssigneeEmailUncached")
+
+	// Create service
+	service, err := AdminService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment := h.Item.(*admin.RoleAssignment)
+
+	var email string
+	if assignment.AssigneeType == "group" {
+		group, err := service.Groups.Get(assignment.AssignedTo).Do()
+		if err != nil {
+			return roleAssignmentLookupResult(err)
+		}
+		email = group.Email
+	} else {
+		user, err := service.Users.Get(assignment.AssignedTo).Projection("basic").Do()
+		if err != nil {
+			return roleAssignmentLookupResult(err)
+		}
+		email = user.PrimaryEmail
+	}
+
+	return email, nil
+}
+
+func getRoleAssignmentOrgUnitPathUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	plugin.Logger(ctx).Trace("getRoleAssignmentOrgUnitPathUncached")
+
+	// Create service
+	service, err := AdminService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Organizational units are looked up by path, or by their ID prefixed with id:
+	orgUnitID := "id:" + strings.TrimPrefix(h.Item.(*admin.RoleAssignment).OrgUnitId, "id:")
+
+	orgUnit, err := service.Orgunits.Get(roleAssignmentCustomerID(d), orgUnitID).Do()
+	if err != nil {
+		return roleAssignmentLookupResult(err)
+	}
+
+	return orgUnit.OrgUnitPath, nil
+}
+
+// Resolves the user key, which may be an email address or an alias of a user or of a group, to the principal's unique ID
+func getRoleAssignmentUserKeyIDUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	plugin.Logger(ctx).Trace("getRoleAssignmentUserKeyIDUncached")
+
+	// Create service
+	service, err := AdminService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	userKey := d.EqualsQualString("user_key")
+	user, err := service.Users.Get(userKey).Projection("basic").Do()
+	if err == nil {
+		return user.Id, nil
+	}
+	if gerr, ok := err.(*googleapi.Error); !ok || gerr.Code != 404 {
+		return nil, err
+	}
+
+	group, err := service.Groups.Get(userKey).Do()
+	if err != nil {
+		return roleAssignmentLookupResult(err)
+	}
+
+	return group.Id, nil
+}
+
+// Returns the customer ID the role assignments are listed for
+func roleAssignmentCustomerID(d *plugin.QueryData) string {
+	// Set default value to my_customer, to represent current account
+	if d.EqualsQuals["customer_id"] != nil {
+		return d.EqualsQuals["customer_id"].GetStringValue()
+	}
+	return "my_customer"
+}
+
+// Returns an empty result for principals and organizational units that no longer exist, or that can't be looked up,
+// e.g. service accounts, so that the lookup is still cached
+func roleAssignmentLookupResult(err error) (interface{}, error) {
+	if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+		return "", nil
+	}
+	return nil, err
+}
+
+// Returns a memoize option caching the given lookup per role, assignee or organizational unit of the role assignment,
+// or per user key
+func withRoleAssignmentCacheKey(lookup string) plugin.MemoizeOption {
+	return func(config *plugin.MemoizeConfiguration) {
+		config.GetCacheKeyFunc = func(_ context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+			assignment := h.Item.(*admin.RoleAssignment)
+
+			var key string
+			switch lookup {
+			case "role_name":
+				key = strconv.FormatInt(assignment.RoleId, 10)
+			case "assignee_email":
+				key = fmt.Sprintf("%s.%s", assignment.AssigneeType, assignment.AssignedTo)
+			case "org_unit_path":
+				key = assignment.OrgUnitId
+			case "user_key_id":
+				key = d.EqualsQualString("user_key")
+			}
+
+			return fmt.Sprintf("googledirectory.role_assignment.%s.%s.%s", lookup, roleAssignmentCustomerID(d), key), nil
+		}
+	}
+}
+
+//// TRANSFORM FUNCTIONS
+
+// Role assignments are indirect if the role is inherited through a group; unknown, if indirect role assignments weren't looked up
+func roleAssignmentIsIndirect(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	viaGroupID, ok := d.Value.(string)
+	if !ok {
+		return nil, nil
+	}
+	return viaGroupID != "", nil
+}
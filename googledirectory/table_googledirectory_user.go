@@ -10,11 +10,12 @@ import (
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 
 	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/googleapi"
 )
 
 //// TABLE DEFINITION
 
-func tableGoogleDirectoryUser(_ context.Context) *plugin.Table {
+func tableGoogleDirectoryUser(_ context.Context, customSchemas []*admin.Schema) *plugin.Table {
 	return &plugin.Table{
 		Name:        "googledirectory_user",
 		Description: "Users defined in the Google Workspace directory.",
@@ -63,7 +64,7 @@ func tableGoogleDirectoryUser(_ context.Context) *plugin.Table {
 			KeyColumns: plugin.AnyColumn([]string{"id", "primary_email"}),
 			Hydrate:    getDirectoryUser,
 		},
-		Columns: []*plugin.Column{
+		Columns: append([]*plugin.Column{
 			{
 				Name:        "full_name",
 				Description: "The user's full name formed by concatenating the first and last name values.",
@@ -311,19 +312,52 @@ func tableGoogleDirectoryUser(_ context.Context) *plugin.Table {
 				Description: "The user's websites.",
 				Type:        proto.ColumnType_JSON,
 			},
-		},
+		}, userCustomSchemaColumns(customSchemas)...),
 	}
 }
 
 //// LIST FUNCTION
 
 func listDirectoryUsers(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// By default, API can return maximum 500 records in a single page
+	return nil, streamDirectoryUsers(ctx, d, maxResults(d, 500))
+}
+
+// Lists the users whose resources are listed by a child table; only the given user, if the user_key qual is set
+func listDirectoryUserKeyUsers(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	userKey := d.EqualsQualString("user_key")
+	if userKey == "" {
+		// The query's limit applies to the child table's rows, not to the users
+		return nil, streamDirectoryUsers(ctx, d, 500)
+	}
+
 	// Create service
 	service, err := AdminService(ctx, d)
 	if err != nil {
 		return nil, err
 	}
 
+	user, err := service.Users.Get(userKey).Do()
+	if err != nil {
+		// Return nil, if given user is not present
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			return nil, nil
+		}
+		return nil, err
+	}
+	d.StreamListItem(ctx, user)
+
+	return nil, nil
+}
+
+// Streams the users matching the query's quals, requesting the given number of users per page
+func streamDirectoryUsers(ctx context.Context, d *plugin.QueryData, pageSize int64) error {
+	// Create service
+	service, err := AdminService(ctx, d)
+	if err != nil {
+		return err
+	}
+
 	equalQuals := d.EqualsQuals
 	quals := d.Quals
 
@@ -347,33 +381,15 @@ func listDirectoryUsers(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 		customerID = d.EqualsQuals["customer_id"].GetStringValue()
 	}
 
-	// By default, API can return maximum 500 records in a single page
-	maxResult := int64(500)
-
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if *limit < maxResult {
-			maxResult = *limit
-		}
-	}
-
-	resp := service.Users.List().Customer(customerID).Query(query).MaxResults(maxResult)
-	if err := resp.Pages(ctx, func(page *admin.Users) error {
-		for _, user := range page.Users {
-			d.StreamListItem(ctx, user)
+	// Request the custom schemas only if required by the query
+	projection, customFieldMask := buildUserProjection(ctx, d)
 
-			// Context can be cancelled due to manual cancellation or the limit has been hit
-			if plugin.IsCancelled(ctx) {
-				page.NextPageToken = ""
-				break
-			}
-		}
-		return nil
-	}); err != nil {
-		return nil, err
+	resp := service.Users.List().Customer(customerID).Query(query).MaxResults(pageSize).Projection(projection)
+	if customFieldMask != "" {
+		resp.CustomFieldMask(customFieldMask)
 	}
 
-	return nil, err
+	return streamPages(ctx, d, resp.Pages, func(page *admin.Users) []*admin.User { return page.Users })
 }
 
 //// HYDRATE FUNCTIONS
@@ -402,7 +418,15 @@ func getDirectoryUser(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrat
 		inputStr = id
 	}
 
-	resp, err := service.Users.Get(inputStr).Do()
+	// Request the custom schemas only if required by the query
+	projection, customFieldMask := buildUserProjection(ctx, d)
+
+	call := service.Users.Get(inputStr).Projection(projection)
+	if customFieldMask != "" {
+		call.CustomFieldMask(customFieldMask)
+	}
+
+	resp, err := call.Do()
 	if err != nil {
 		return nil, err
 	}
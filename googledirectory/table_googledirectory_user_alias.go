@@ -0,0 +1,13 @@
+package googledirectory
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+//// TABLE DEFINITION
+
+func tableGoogleDirectoryUserAlias(_ context.Context) *plugin.Table {
+	return tableGoogleDirectoryAlias("googledirectory_user_alias", "User alias email addresses defined in the Google Workspace directory.", userAliasOwnerKind)
+}
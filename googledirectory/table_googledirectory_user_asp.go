@@ -0,0 +1,107 @@
+package googledirectory
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+type userAsp struct {
+	UserKey      string
+	UserId       string
+	PrimaryEmail string
+	Asp          *admin.Asp
+}
+
+//// TABLE DEFINITION
+
+func tableGoogleDirectoryUserAsp(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googledirectory_user_asp",
+		Description: "Application-specific passwords (ASPs) issued by users in the Google Workspace directory.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listDirectoryUserKeyUsers,
+			Hydrate:       listDirectoryUserAsps,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "user_key",
+					Require: plugin.Optional,
+				},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "user_key",
+				Description: "The user's primary email address, alias email address, or unique user ID.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "code_id",
+				Description: "The unique ID of the ASP.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Asp.CodeId"),
+			},
+			{
+				Name:        "name",
+				Description: "The name of the application that the user, represented by their user ID, entered when the ASP was created.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Asp.Name"),
+			},
+			{
+				Name:        "user_id",
+				Description: "The unique ID of the user who issued the ASP.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "primary_email",
+				Description: "The primary email address of the user who issued the ASP.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "creation_time",
+				Description: "The time when the ASP was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Asp.CreationTime").Transform(transform.UnixMsToTimestamp),
+			},
+			{
+				Name:        "last_time_used",
+				Description: "The time when the ASP was last used.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Asp.LastTimeUsed").Transform(transform.UnixMsToTimestamp),
+			},
+			{
+				Name:        "etag",
+				Description: "A hash of the metadata, used to ensure there were no concurrent modifications to the resource when attempting an update.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Asp.Etag"),
+			},
+			{
+				Name:        "kind",
+				Description: "The type of the API resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Asp.Kind"),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectoryUserAsps(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	list := func(service *admin.Service, userID string) ([]*admin.Asp, error) {
+		resp, err := service.Asps.List(userID).Do()
+		if err != nil {
+			return nil, err
+		}
+		return resp.Items, nil
+	}
+	row := func(user *admin.User, userKey string, asp *admin.Asp) userAsp {
+		return userAsp{UserKey: userKey, UserId: user.Id, PrimaryEmail: user.PrimaryEmail, Asp: asp}
+	}
+	return streamUserSecurityItems(ctx, d, h, list, row)
+}
@@ -0,0 +1,408 @@
+package googledirectory
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/googleapi"
+)
+
+type userEffectivePrivilege struct {
+	UserId               string
+	PrimaryEmail         string
+	PrivilegeName        string
+	ServiceId            string
+	ServiceName          string
+	GrantedPrivilegeName string
+	ScopeType            string
+	OrgUnitId            string
+	ViaRoleId            string
+	ViaRoleName          string
+	IsSuperAdminRole     bool
+	RoleAssignmentId     string
+	ViaGroupId           string
+}
+
+// effectiveRoleAssignment is a role assignment granting privileges to a user, either directly or through a group
+type effectiveRoleAssignment struct {
+	Assignment   *admin.RoleAssignment
+	UserId       string
+	PrimaryEmail string
+	ViaGroupId   string
+}
+
+// privilegeKey identifies a privilege; privilege names are only unique within a service
+type privilegeKey struct {
+	ServiceId     string
+	PrivilegeName string
+}
+
+//// TABLE DEFINITION
+
+func tableGoogleDirectoryUserEffectivePrivilege(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googledirectory_user_effective_privilege",
+		Description: "The privileges granted to users through their admin role assignments in the Google Workspace directory.",
+		List: &plugin.ListConfig{
+			Hydrate: listDirectoryUserEffectivePrivileges,
+			Tags:    map[string]string{"service": "admin", "action": "members.list"},
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "customer_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "user_key",
+					Require: plugin.Optional,
+				},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "user_id",
+				Description: "The unique ID of the user the privilege is granted to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "primary_email",
+				Description: "The primary email address of the user the privilege is granted to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "privilege_name",
+				Description: "The name of the privilege.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "service_name",
+				Description: "The name of the service this privilege is for.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "service_id",
+				Description: "The obfuscated ID of the service this privilege is for.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "granted_privilege_name",
+				Description: "The name of the privilege of the role that grants this privilege; differs from privilege_name if the privilege is a child of the role's privilege.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "scope_type",
+				Description: "The scope in which the role granting the privilege is assigned.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "org_unit_id",
+				Description: "If the role is restricted to an organization unit, this contains the ID for the organization unit the exercise of the privilege is restricted to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "via_role_id",
+				Description: "The unique ID of the role granting the privilege.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "via_role_name",
+				Description: "The name of the role granting the privilege.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "is_super_admin_role",
+				Description: "Indicates whether the role granting the privilege is a super admin role, which grants every privilege.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "role_assignment_id",
+				Description: "The unique ID of the role assignment granting the privilege.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "via_group_id",
+				Description: "The unique ID of the group the role granting the privilege is assigned to, if the privilege is granted through the user's membership of the group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "customer_id",
+				Description: "The customer ID to retrieve all account privileges.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("customer_id"),
+			},
+			{
+				Name:        "user_key",
+				Description: "The user's primary email address, alias email address, or unique user ID.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("user_key"),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectoryUserEffectivePrivileges(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create service
+	service, err := AdminService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default value to my_customer, to represent current account
+	customerID := "my_customer"
+	if d.EqualsQuals["customer_id"] != nil {
+		customerID = d.EqualsQuals["customer_id"].GetStringValue()
+	}
+
+	// List the privileges, to expand the privileges of each role to their child privileges
+	privilegesResp, err := service.Privileges.List(customerID).Do()
+	if err != nil {
+		return nil, err
+	}
+	privileges := buildPrivilegeIndex(privilegesResp.Items)
+
+	// List the roles, to resolve the privileges granted by each assignment
+	roles := map[int64]*admin.Role{}
+	if err := service.Roles.List(customerID).Pages(ctx, func(page *admin.Roles) error {
+		for _, role := range page.Items {
+			roles[role.RoleId] = role
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var assignments, groupAssignments []*effectiveRoleAssignment
+	resp := service.RoleAssignments.List(customerID)
+	if userKey := d.EqualsQualString("user_key"); userKey != "" {
+		// Resolve the user, to attribute the roles assigned to the user's groups to the user
+		user, err := service.Users.Get(userKey).Projection("basic").Do()
+		if err != nil {
+			// Return nil, if given user is not present
+			if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		// Include the roles assigned to the groups the user is a member of
+		if err := resp.UserKey(userKey).IncludeIndirectRoleAssignments(true).Pages(ctx, func(page *admin.RoleAssignments) error {
+			for _, assignment := range page.Items {
+				effective := &effectiveRoleAssignment{Assignment: assignment, UserId: user.Id, PrimaryEmail: user.PrimaryEmail}
+				if assignment.AssigneeType == "group" {
+					effective.ViaGroupId = assignment.AssignedTo
+				}
+				assignments = append(assignments, effective)
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := resp.Pages(ctx, func(page *admin.RoleAssignments) error {
+			for _, assignment := range page.Items {
+				effective := &effectiveRoleAssignment{Assignment: assignment, UserId: assignment.AssignedTo}
+				if assignment.AssigneeType == "group" {
+					groupAssignments = append(groupAssignments, effective)
+					continue
+				}
+				assignments = append(assignments, effective)
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	// Expand the roles assigned to groups to the users that are direct or indirect members of the groups
+	groupUsers := map[string][]*admin.Member{}
+	for _, groupAssignment := range groupAssignments {
+		groupID := groupAssignment.Assignment.AssignedTo
+		if _, ok := groupUsers[groupID]; !ok {
+			users, err := listGroupUserMembers(ctx, d, service, groupID)
+			if err != nil {
+				return nil, err
+			}
+			groupUsers[groupID] = users
+		}
+		for _, member := range groupUsers[groupID] {
+			assignments = append(assignments, &effectiveRoleAssignment{
+				Assignment:   groupAssignment.Assignment,
+				UserId:       member.Id,
+				PrimaryEmail: member.Email,
+				ViaGroupId:   groupID,
+			})
+		}
+	}
+
+	// Only look up the users' email addresses if requested, and not already known
+	if slices.Contains(d.QueryContext.Columns, "primary_email") {
+		primaryEmails := map[string]string{}
+		for _, effective := range assignments {
+			if effective.PrimaryEmail != "" {
+				continue
+			}
+			primaryEmail, ok := primaryEmails[effective.UserId]
+			if !ok {
+				user, err := service.Users.Get(effective.UserId).Projection("basic").Do()
+				if err != nil {
+					// The role may be assigned to a user that can't be looked up, e.g. a service account
+					if gerr, ok := err.(*googleapi.Error); !ok || gerr.Code != 404 {
+						return nil, err
+					}
+				} else {
+					primaryEmail = user.PrimaryEmail
+				}
+				primaryEmails[effective.UserId] = primaryEmail
+			}
+			effective.PrimaryEmail = primaryEmail
+		}
+	}
+
+	for _, effective := range assignments {
+		assignment := effective.Assignment
+		role, ok := roles[assignment.RoleId]
+		if !ok {
+			plugin.Logger(ctx).Warn("googledirectory_user_effective_privilege.listDirectoryUserEffectivePrivileges", "role_not_found", assignment.RoleId)
+			continue
+		}
+
+		rows := []*userEffectivePrivilege{}
+		for _, privilege := range expandRolePrivileges(role, privileges) {
+			rows = append(rows, &userEffectivePrivilege{
+				UserId:               effective.UserId,
+				PrimaryEmail:         effective.PrimaryEmail,
+				PrivilegeName:        privilege.PrivilegeName,
+				ServiceId:            privilege.ServiceId,
+				ServiceName:          privilege.ServiceName,
+				GrantedPrivilegeName: privilege.GrantedPrivilegeName,
+				ScopeType:            assignment.ScopeType,
+				OrgUnitId:            assignment.OrgUnitId,
+				ViaRoleId:            strconv.FormatInt(role.RoleId, 10),
+				ViaRoleName:          role.RoleName,
+				IsSuperAdminRole:     role.IsSuperAdminRole,
+				RoleAssignmentId:     strconv.FormatInt(assignment.RoleAssignmentId, 10),
+				ViaGroupId:           effective.ViaGroupId,
+			})
+		}
+		if !streamItems(ctx, d, rows) {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Returns the users that are direct or indirect members of the given group
+func listGroupUserMembers(ctx context.Context, d *plugin.QueryData, service *admin.Service, groupID string) ([]*admin.Member, error) {
+	users := []*admin.Member{}
+
+	// Share the members list rate limit with googledirectory_group_member, as the list makes one call per group with assigned roles
+	d.WaitForListRateLimit(ctx)
+
+	// By default, API can return maximum 200 records in a single page
+	if err := service.Members.List(groupID).IncludeDerivedMembership(true).MaxResults(200).Pages(ctx, func(page *admin.Members) error {
+		for _, member := range page.Members {
+			if member.Type == "USER" {
+				users = append(users, member)
+			}
+		}
+		return nil
+	}); err != nil {
+		// Return no users, if the group can't be found, e.g. it has been deleted
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			return users, nil
+		}
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// expandedPrivilege is a privilege granted by a role, either directly or as a child of a privilege of the role
+type expandedPrivilege struct {
+	PrivilegeName        string
+	ServiceId            string
+	ServiceName          string
+	GrantedPrivilegeName string
+}
+
+// Returns every privilege of the given privilege trees, indexed by service and name
+func buildPrivilegeIndex(privileges []*admin.Privilege) map[privilegeKey]*admin.Privilege {
+	index := map[privilegeKey]*admin.Privilege{}
+	var walk func([]*admin.Privilege)
+	walk = func(privileges []*admin.Privilege) {
+		for _, privilege := range privileges {
+			key := privilegeKey{ServiceId: privilege.ServiceId, PrivilegeName: privilege.PrivilegeName}
+			if _, ok := index[key]; ok {
+				continue
+			}
+			index[key] = privilege
+			walk(privilege.ChildPrivileges)
+		}
+	}
+	walk(privileges)
+	return index
+}
+
+// Returns the privileges granted by the given role, including the child privileges of the role's privileges.
+// Super admin roles grant every privilege.
+func expandRolePrivileges(role *admin.Role, privileges map[privilegeKey]*admin.Privilege) []expandedPrivilege {
+	granted := map[privilegeKey]bool{}
+	expanded := []expandedPrivilege{}
+
+	var grant func(privilege *admin.Privilege, grantedPrivilegeName string, withChildren bool)
+	grant = func(privilege *admin.Privilege, grantedPrivilegeName string, withChildren bool) {
+		key := privilegeKey{ServiceId: privilege.ServiceId, PrivilegeName: privilege.PrivilegeName}
+		if granted[key] {
+			return
+		}
+		granted[key] = true
+		expanded = append(expanded, expandedPrivilege{
+			PrivilegeName:        privilege.PrivilegeName,
+			ServiceId:            privilege.ServiceId,
+			ServiceName:          privilege.ServiceName,
+			GrantedPrivilegeName: grantedPrivilegeName,
+		})
+		if withChildren {
+			for _, child := range privilege.ChildPrivileges {
+				grant(child, grantedPrivilegeName, true)
+			}
+		}
+	}
+
+	if role.IsSuperAdminRole {
+		// The index already contains every child privilege
+		for _, privilege := range privileges {
+			grant(privilege, privilege.PrivilegeName, false)
+		}
+	} else {
+		for _, rolePrivilege := range role.RolePrivileges {
+			key := privilegeKey{ServiceId: rolePrivilege.ServiceId, PrivilegeName: rolePrivilege.PrivilegeName}
+			privilege, ok := privileges[key]
+			if !ok {
+				// Keep privileges that are not listed for the customer, e.g. for a service that is no longer available
+				privilege = &admin.Privilege{PrivilegeName: rolePrivilege.PrivilegeName, ServiceId: rolePrivilege.ServiceId}
+			}
+			grant(privilege, rolePrivilege.PrivilegeName, true)
+		}
+	}
+
+	// Return the privileges in a stable order
+	slices.SortFunc(expanded, func(a, b expandedPrivilege) int {
+		if a.ServiceId != b.ServiceId {
+			return strings.Compare(a.ServiceId, b.ServiceId)
+		}
+		return strings.Compare(a.PrivilegeName, b.PrivilegeName)
+	})
+
+	return expanded
+}
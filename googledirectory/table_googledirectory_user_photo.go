@@ -0,0 +1,147 @@
+package googledirectory
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+type userPhoto struct {
+	UserKey string
+	Photo   *admin.UserPhoto
+}
+
+//// TABLE DEFINITION
+
+func tableGoogleDirectoryUserPhoto(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googledirectory_user_photo",
+		Description: "User profile photos defined in the Google Workspace directory.",
+		List: &plugin.ListConfig{
+			ParentHydrate:     listDirectoryUserKeyUsers,
+			Hydrate:           listDirectoryUserPhotos,
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("user_key"),
+			Hydrate:    getDirectoryUserPhoto,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "user_key",
+				Description: "The user's primary email address, alias email address, or unique user ID.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The unique ID of the user.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Photo.Id"),
+			},
+			{
+				Name:        "primary_email",
+				Description: "The user's primary email address.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Photo.PrimaryEmail"),
+			},
+			{
+				Name:        "mime_type",
+				Description: "The MIME type of the photo.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Photo.MimeType"),
+			},
+			{
+				Name:        "width",
+				Description: "Width of the photo in pixels.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Photo.Width"),
+			},
+			{
+				Name:        "height",
+				Description: "Height of the photo in pixels.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Photo.Height"),
+			},
+			{
+				Name:        "etag",
+				Description: "A hash of the metadata, used to ensure there were no concurrent modifications to the resource when attempting an update.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Photo.Etag"),
+			},
+			{
+				Name:        "kind",
+				Description: "The type of the API resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Photo.Kind"),
+			},
+			{
+				Name:        "photo_data",
+				Description: "The photo data in web-safe Base64 format, i.e. with / replaced by _, + replaced by -, and = replaced by *.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Photo.PhotoData"),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectoryUserPhotos(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	user := h.Item.(*admin.User)
+
+	// Return nil, if the user doesn't have a photo
+	if user.ThumbnailPhotoUrl == "" {
+		return nil, nil
+	}
+
+	// Create service
+	service, err := AdminService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := service.Users.Photos.Get(user.Id).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	d.StreamListItem(ctx, userPhoto{
+		UserKey: user.PrimaryEmail,
+		Photo:   resp,
+	})
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getDirectoryUserPhoto(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	plugin.Logger(ctx).Trace("getDirectoryUserPhoto")
+
+	// Create service
+	service, err := AdminService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	userKey := d.EqualsQuals["user_key"].GetStringValue()
+
+	// Return nil, if no input provided
+	if userKey == "" {
+		return nil, nil
+	}
+
+	resp, err := service.Users.Photos.Get(userKey).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	return userPhoto{
+		UserKey: userKey,
+		Photo:   resp,
+	}, nil
+}
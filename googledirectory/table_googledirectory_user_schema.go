@@ -0,0 +1,149 @@
+package googledirectory
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+//// TABLE DEFINITION
+
+func tableGoogleDirectoryUserSchema(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googledirectory_user_schema",
+		Description: "Custom user schemas defined in the Google Workspace directory.",
+		List: &plugin.ListConfig{
+			Hydrate: listDirectoryUserSchemas,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "customer_id",
+					Require: plugin.Optional,
+				},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "schema_id",
+					Require: plugin.AnyOf,
+				},
+				{
+					Name:    "schema_name",
+					Require: plugin.AnyOf,
+				},
+				{
+					Name:    "customer_id",
+					Require: plugin.Optional,
+				},
+			},
+			Hydrate: getDirectoryUserSchema,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "schema_name",
+				Description: "The schema's name.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "schema_id",
+				Description: "The unique identifier of the schema.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "display_name",
+				Description: "Display name for the schema.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "customer_id",
+				Description: "The customer ID to retrieve all account schemas.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("customer_id"),
+			},
+			{
+				Name:        "etag",
+				Description: "A hash of the metadata, used to ensure there were no concurrent modifications to the resource when attempting an update.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "kind",
+				Description: "The type of the API resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "fields",
+				Description: "A list of fields in the schema, including each field's name, type, whether it is multi-valued or indexed, its read access type and its numeric indexing spec.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectoryUserSchemas(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create service
+	service, err := AdminServiceWithScope(ctx, d, admin.AdminDirectoryUserschemaReadonlyScope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default value to my_customer, to represent current account
+	customerID := "my_customer"
+	if d.EqualsQuals["customer_id"] != nil {
+		customerID = d.EqualsQuals["customer_id"].GetStringValue()
+	}
+
+	resp, err := service.Schemas.List(customerID).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	streamItems(ctx, d, resp.Schemas)
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getDirectoryUserSchema(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	plugin.Logger(ctx).Trace("getDirectoryUserSchema")
+
+	// Create service
+	service, err := AdminServiceWithScope(ctx, d, admin.AdminDirectoryUserschemaReadonlyScope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default value to my_customer, to represent current account
+	customerID := "my_customer"
+	if d.EqualsQuals["customer_id"] != nil {
+		customerID = d.EqualsQuals["customer_id"].GetStringValue()
+	}
+	schemaID := d.EqualsQuals["schema_id"].GetStringValue()
+	schemaName := d.EqualsQuals["schema_name"].GetStringValue()
+
+	// Return nil, if no input provided
+	if schemaID == "" && schemaName == "" {
+		return nil, nil
+	}
+
+	var inputStr string
+	if schemaID == "" {
+		inputStr = schemaName
+	} else {
+		inputStr = schemaID
+	}
+
+	resp, err := service.Schemas.Get(customerID, inputStr).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,168 @@
+package googledirectory
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/googleapi"
+)
+
+type userSchemaField struct {
+	SchemaId   string
+	SchemaName string
+	Field      *admin.SchemaFieldSpec
+}
+
+//// TABLE DEFINITION
+
+func tableGoogleDirectoryUserSchemaField(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googledirectory_user_schema_field",
+		Description: "Fields of the custom user schemas defined in the Google Workspace directory.",
+		List: &plugin.ListConfig{
+			Hydrate: listDirectoryUserSchemaFields,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "customer_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "schema_name",
+					Require: plugin.Optional,
+				},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "schema_name",
+				Description: "The name of the schema the field belongs to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "field_name",
+				Description: "The name of the field.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Field.FieldName"),
+			},
+			{
+				Name:        "field_id",
+				Description: "The unique identifier of the field.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Field.FieldId"),
+			},
+			{
+				Name:        "field_type",
+				Description: "The type of the field, e.g. STRING, INT64, BOOL, DOUBLE, EMAIL, PHONE or DATE.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Field.FieldType"),
+			},
+			{
+				Name:        "display_name",
+				Description: "Display name of the field.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Field.DisplayName"),
+			},
+			{
+				Name:        "multi_valued",
+				Description: "Indicates whether the field holds a list of values, or a single value.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Field.MultiValued"),
+			},
+			{
+				Name:        "indexed",
+				Description: "Indicates whether the field is indexed, and can be used to search users.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Field.Indexed"),
+			},
+			{
+				Name:        "read_access_type",
+				Description: "Specifies who can view values of the field, either ALL_DOMAIN_USERS or ADMINS_AND_SELF.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Field.ReadAccessType"),
+			},
+			{
+				Name:        "numeric_indexing_spec_min_value",
+				Description: "The minimum value of the numeric range of the field, used to optimize searches on numeric fields.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Field.NumericIndexingSpec.MinValue"),
+			},
+			{
+				Name:        "numeric_indexing_spec_max_value",
+				Description: "The maximum value of the numeric range of the field, used to optimize searches on numeric fields.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Field.NumericIndexingSpec.MaxValue"),
+			},
+			{
+				Name:        "schema_id",
+				Description: "The unique identifier of the schema the field belongs to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "customer_id",
+				Description: "The customer ID to retrieve all account schemas.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("customer_id"),
+			},
+			{
+				Name:        "etag",
+				Description: "A hash of the metadata, used to ensure there were no concurrent modifications to the resource when attempting an update.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Field.Etag"),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectoryUserSchemaFields(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create service
+	service, err := AdminServiceWithScope(ctx, d, admin.AdminDirectoryUserschemaReadonlyScope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default value to my_customer, to represent current account
+	customerID := "my_customer"
+	if d.EqualsQuals["customer_id"] != nil {
+		customerID = d.EqualsQuals["customer_id"].GetStringValue()
+	}
+
+	var schemas []*admin.Schema
+	if schemaName := d.EqualsQualString("schema_name"); schemaName != "" {
+		schema, err := service.Schemas.Get(customerID, schemaName).Do()
+		if err != nil {
+			// Return nil, if given schema is not present
+			if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+				return nil, nil
+			}
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	} else {
+		resp, err := service.Schemas.List(customerID).Do()
+		if err != nil {
+			return nil, err
+		}
+		schemas = resp.Schemas
+	}
+
+	fields := []userSchemaField{}
+	for _, schema := range schemas {
+		for _, field := range schema.Fields {
+			fields = append(fields, userSchemaField{
+				SchemaId:   schema.SchemaId,
+				SchemaName: schema.SchemaName,
+				Field:      field,
+			})
+		}
+	}
+	streamItems(ctx, d, fields)
+
+	return nil, nil
+}
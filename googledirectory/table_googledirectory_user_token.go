@@ -0,0 +1,113 @@
+package googledirectory
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+type userToken struct {
+	UserKey      string
+	UserId       string
+	PrimaryEmail string
+	Token        *admin.Token
+}
+
+//// TABLE DEFINITION
+
+func tableGoogleDirectoryUserToken(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googledirectory_user_token",
+		Description: "OAuth access tokens issued by users to third-party applications in the Google Workspace directory.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listDirectoryUserKeyUsers,
+			Hydrate:       listDirectoryUserTokens,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "user_key",
+					Require: plugin.Optional,
+				},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "user_key",
+				Description: "The user's primary email address, alias email address, or unique user ID.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "client_id",
+				Description: "The Client ID of the application the token is issued to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Token.ClientId"),
+			},
+			{
+				Name:        "display_text",
+				Description: "The displayable name of the application the token is issued to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Token.DisplayText"),
+			},
+			{
+				Name:        "user_id",
+				Description: "The unique ID of the user that issued the token.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "primary_email",
+				Description: "The primary email address of the user that issued the token.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "anonymous",
+				Description: "Indicates whether the application is registered with Google, or not.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Token.Anonymous"),
+			},
+			{
+				Name:        "native_app",
+				Description: "Indicates whether the token is issued to an installed application, or not.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Token.NativeApp"),
+			},
+			{
+				Name:        "etag",
+				Description: "A hash of the metadata, used to ensure there were no concurrent modifications to the resource when attempting an update.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Token.Etag"),
+			},
+			{
+				Name:        "kind",
+				Description: "The type of the API resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Token.Kind"),
+			},
+			{
+				Name:        "scopes",
+				Description: "A list of authorization scopes the application is granted.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Token.Scopes"),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectoryUserTokens(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	list := func(service *admin.Service, userID string) ([]*admin.Token, error) {
+		resp, err := service.Tokens.List(userID).Do()
+		if err != nil {
+			return nil, err
+		}
+		return resp.Items, nil
+	}
+	row := func(user *admin.User, userKey string, token *admin.Token) userToken {
+		return userToken{UserKey: userKey, UserId: user.Id, PrimaryEmail: user.PrimaryEmail, Token: token}
+	}
+	return streamUserSecurityItems(ctx, d, h, list, row)
+}
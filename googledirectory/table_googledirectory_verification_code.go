@@ -0,0 +1,104 @@
+package googledirectory
+
+import (
+	"context"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+type userVerificationCode struct {
+	UserKey          string
+	PrimaryEmail     string
+	VerificationCode *admin.VerificationCode
+}
+
+//// TABLE DEFINITION
+
+func tableGoogleDirectoryVerificationCode(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googledirectory_verification_code",
+		Description: "2-step verification backup codes generated for users in the Google Workspace directory.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listDirectoryUserKeyUsers,
+			Hydrate:       listDirectoryVerificationCodes,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "user_key",
+					Require: plugin.Optional,
+				},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "user_key",
+				Description: "The user's primary email address, alias email address, or unique user ID.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "user_id",
+				Description: "The unique ID of the user.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("VerificationCode.UserId"),
+			},
+			{
+				Name:        "primary_email",
+				Description: "The user's primary email address.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "verification_code",
+				Description: "A current verification code for the user. Invalidated or used verification codes are not returned. The code is masked if `mask_verification_codes` is set in the connection config.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("VerificationCode.VerificationCode"),
+			},
+			{
+				Name:        "etag",
+				Description: "A hash of the metadata, used to ensure there were no concurrent modifications to the resource when attempting an update.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("VerificationCode.Etag"),
+			},
+			{
+				Name:        "kind",
+				Description: "The type of the API resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("VerificationCode.Kind"),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectoryVerificationCodes(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	googledirectoryConfig := GetConfig(d.Connection)
+	maskCodes := googledirectoryConfig.MaskVerificationCodes != nil && *googledirectoryConfig.MaskVerificationCodes
+
+	list := func(service *admin.Service, userID string) ([]*admin.VerificationCode, error) {
+		resp, err := service.VerificationCodes.List(userID).Do()
+		if err != nil {
+			return nil, err
+		}
+		return resp.Items, nil
+	}
+	row := func(user *admin.User, userKey string, code *admin.VerificationCode) userVerificationCode {
+		if maskCodes {
+			code.VerificationCode = maskVerificationCode(code.VerificationCode)
+		}
+		return userVerificationCode{UserKey: userKey, PrimaryEmail: user.PrimaryEmail, VerificationCode: code}
+	}
+	return streamUserSecurityItems(ctx, d, h, list, row)
+}
+
+// Masks all but the last two characters of the given verification code
+func maskVerificationCode(code string) string {
+	if len(code) <= 2 {
+		return strings.Repeat("*", len(code))
+	}
+	return strings.Repeat("*", len(code)-2) + code[len(code)-2:]
+}
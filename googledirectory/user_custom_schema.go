@@ -0,0 +1,168 @@
+package googledirectory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/turbot/steampipe-plugin-sdk/v5/connection"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+// userCustomSchemaField describes a field of a custom user schema, surfaced as a column of the googledirectory_user table
+type userCustomSchemaField struct {
+	ColumnName  string
+	SchemaName  string
+	FieldName   string
+	DisplayName string
+	FieldType   string
+	MultiValued bool
+}
+
+// Returns the custom user schemas defined in the connection's account
+func listUserCustomSchemas(ctx context.Context, conn *plugin.Connection, connectionCache *connection.ConnectionCache) ([]*admin.Schema, error) {
+	// have we already fetched and cached the schemas?
+	cacheKey := "googledirectory.user_custom_schemas"
+	if cachedData, ok := connectionCache.Get(ctx, cacheKey); ok {
+		return cachedData.([]*admin.Schema), nil
+	}
+
+	// Create service
+	service, err := adminService(ctx, conn, connectionCache, admin.AdminDirectoryUserschemaReadonlyScope)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := service.Schemas.List("my_customer").Do()
+	if err != nil {
+		return nil, err
+	}
+
+	// cache the schemas
+	connectionCache.Set(ctx, cacheKey, resp.Schemas)
+
+	return resp.Schemas, nil
+}
+
+// Returns the fields of the given custom user schemas, along with the name of the column each field is surfaced as,
+// e.g. the field CostCenter of the schema Employment is surfaced as custom_employment_cost_center
+func buildUserCustomSchemaFields(schemas []*admin.Schema) []userCustomSchemaField {
+	fields := []userCustomSchemaField{}
+	columnNames := map[string]bool{}
+	for _, schema := range schemas {
+		for _, field := range schema.Fields {
+			columnName := fmt.Sprintf("custom_%s_%s", strcase.ToSnake(schema.SchemaName), strcase.ToSnake(field.FieldName))
+
+			// Skip fields whose names collide with a column already added
+			if columnNames[columnName] {
+				continue
+			}
+			columnNames[columnName] = true
+
+			fields = append(fields, userCustomSchemaField{
+				ColumnName:  columnName,
+				SchemaName:  schema.SchemaName,
+				FieldName:   field.FieldName,
+				DisplayName: field.DisplayName,
+				FieldType:   field.FieldType,
+				MultiValued: field.MultiValued,
+			})
+		}
+	}
+	return fields
+}
+
+// Returns a column for each field of the given custom user schemas
+func userCustomSchemaColumns(schemas []*admin.Schema) []*plugin.Column {
+	columns := []*plugin.Column{}
+	for _, field := range buildUserCustomSchemaFields(schemas) {
+		description := fmt.Sprintf("The %s field of the %s custom schema.", field.FieldName, field.SchemaName)
+		if field.DisplayName != "" {
+			description = fmt.Sprintf("%s (%s)", description, field.DisplayName)
+		}
+		columns = append(columns, &plugin.Column{
+			Name:        field.ColumnName,
+			Description: description,
+			Type:        userCustomSchemaColumnType(field),
+			Transform:   transform.FromP(userCustomSchemaFieldValue, field),
+		})
+	}
+	return columns
+}
+
+// Maps the type of a custom schema field to a column type; multi-valued fields are returned as JSON
+func userCustomSchemaColumnType(field userCustomSchemaField) proto.ColumnType {
+	if field.MultiValued {
+		return proto.ColumnType_JSON
+	}
+
+	switch field.FieldType {
+	case "BOOL":
+		return proto.ColumnType_BOOL
+	case "INT64":
+		return proto.ColumnType_INT
+	case "DOUBLE":
+		return proto.ColumnType_DOUBLE
+	case "DATE":
+		return proto.ColumnType_TIMESTAMP
+	default:
+		return proto.ColumnType_STRING
+	}
+}
+
+// Returns the projection and the custom field mask to list or get users with, based on the columns requested by the query.
+// Custom schemas are only requested when the custom_schemas column, or a custom schema field column, is selected.
+func buildUserProjection(ctx context.Context, d *plugin.QueryData) (string, string) {
+	if slices.Contains(d.QueryContext.Columns, "custom_schemas") {
+		return "full", ""
+	}
+
+	// Only look up the custom schemas if a custom schema field column may have been requested
+	if !slices.ContainsFunc(d.QueryContext.Columns, func(column string) bool { return strings.HasPrefix(column, "custom_") }) {
+		return "basic", ""
+	}
+
+	schemas, err := listUserCustomSchemas(ctx, d.Connection, d.ConnectionCache)
+	if err != nil {
+		plugin.Logger(ctx).Error("googledirectory_user.buildUserProjection", "schema_error", err)
+		return "full", ""
+	}
+
+	var schemaNames []string
+	for _, field := range buildUserCustomSchemaFields(schemas) {
+		if slices.Contains(d.QueryContext.Columns, field.ColumnName) && !slices.Contains(schemaNames, field.SchemaName) {
+			schemaNames = append(schemaNames, field.SchemaName)
+		}
+	}
+
+	if len(schemaNames) > 0 {
+		return "custom", strings.Join(schemaNames, ",")
+	}
+	return "basic", ""
+}
+
+//// TRANSFORM FUNCTIONS
+
+func userCustomSchemaFieldValue(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	user := d.HydrateItem.(*admin.User)
+	field := d.Param.(userCustomSchemaField)
+
+	raw, ok := user.CustomSchemas[field.SchemaName]
+	if !ok {
+		return nil, nil
+	}
+
+	var values map[string]interface{}
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, err
+	}
+
+	return values[field.FieldName], nil
+}
@@ -0,0 +1,40 @@
+package googledirectory
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+// Streams the security items of the user listed by listDirectoryUserKeyUsers, e.g. the user's tokens or ASPs,
+// listed with the given list function and wrapped with the user they belong to by the given row function
+func streamUserSecurityItems[T any, R any](ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, list func(service *admin.Service, userID string) ([]T, error), row func(user *admin.User, userKey string, item T) R) (interface{}, error) {
+	user := h.Item.(*admin.User)
+
+	// Keep the given user key, which may be an alias or the unique ID of the user
+	userKey := d.EqualsQualString("user_key")
+	if userKey == "" {
+		userKey = user.PrimaryEmail
+	}
+
+	// Create service
+	service, err := AdminServiceWithScope(ctx, d, admin.AdminDirectoryUserSecurityScope)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := list(service, user.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []R{}
+	for _, item := range items {
+		rows = append(rows, row(user, userKey, item))
+	}
+	streamItems(ctx, d, rows)
+
+	return nil, nil
+}
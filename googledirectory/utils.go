@@ -1,10 +1,13 @@
 package googledirectory
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 
 	"github.com/mitchellh/go-homedir"
+
+	admin "google.golang.org/api/admin/directory/v1"
 )
 
 // Returns the content of given file, or the inline JSON credential as it is
@@ -49,3 +52,18 @@ func expandPath(filePath string) (string, error) {
 	}
 	return path, nil
 }
+
+// Converts the untyped items returned by the aliases list APIs to aliases
+func toAliases(items []interface{}) ([]*admin.Alias, error) {
+	aliases := []*admin.Alias{}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &aliases); err != nil {
+		return nil, err
+	}
+
+	return aliases, nil
+}